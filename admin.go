@@ -0,0 +1,222 @@
+package messaging
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/more-than-code/messaging/pb"
+	"github.com/more-than-code/messaging/repository"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRecentSends = 50
+	maxRecentSends     = 1000
+)
+
+// AdminServer implements the MessagingAdmin service used by support staff.
+type AdminServer struct {
+	repo *repository.Repository
+	cfg  *ServerConfig
+	pb.UnimplementedMessagingAdminServer
+}
+
+// adminAuthInterceptor rejects MessagingAdmin calls that don't carry token
+// as a bearer credential. Other services pass through.
+func adminAuthInterceptor(token string) grpc.UnaryServerInterceptor {
+	prefix := "/" + pb.MessagingAdmin_ServiceDesc.ServiceName + "/"
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, prefix) {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		var presented string
+		if values := md.Get("authorization"); len(values) > 0 {
+			presented = strings.TrimPrefix(values[0], "Bearer ")
+		}
+
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			log.Printf("[Admin] Rejected unauthenticated call to %s", info.FullMethod)
+			return nil, status.Error(codes.Unauthenticated, "invalid admin credential")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// normalizeIdentity keys an optional phone_or_email the same way the
+// Messaging service does, so staff can paste a number in any format.
+func (s *AdminServer) normalizeIdentity(phoneOrEmail string) (string, error) {
+	if phoneOrEmail == "" {
+		return "", nil
+	}
+
+	normalized, err := normalizeIdentity(phoneOrEmail, s.cfg.DefaultPhoneRegion)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid phone_or_email: %v", err)
+	}
+
+	return normalized, nil
+}
+
+func (s *AdminServer) GetPendingVerification(ctx context.Context, req *pb.GetPendingVerificationRequest) (*pb.GetPendingVerificationResponse, error) {
+	phoneOrEmail, err := s.normalizeIdentity(req.PhoneOrEmail)
+	if err != nil {
+		return nil, err
+	}
+	req.PhoneOrEmail = phoneOrEmail
+
+	found, err := s.repo.GetVerificationInfo(ctx, req.PhoneOrEmail, req.Purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[Admin] Looked up pending verification for %s, purpose %q", req.PhoneOrEmail, req.Purpose)
+
+	if found == nil {
+		return &pb.GetPendingVerificationResponse{}, nil
+	}
+
+	code, err := s.repo.RecoverVerificationCode(found)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.GetPendingVerificationResponse{
+		Found:          true,
+		MaskedCode:     maskCode(code),
+		Attempts:       int32(found.Attempt),
+		LastAttempt:    found.LastAttempt.Unix(),
+		DeliveryFailed: found.DeliveryFailed,
+		Link:           found.Link,
+	}
+	if !found.ExpiresAt.IsZero() {
+		res.TtlSeconds = durationToSeconds(time.Until(found.ExpiresAt))
+	}
+
+	return res, nil
+}
+
+func (s *AdminServer) RevokeVerification(ctx context.Context, req *pb.RevokeVerificationRequest) (*pb.RevokeVerificationResponse, error) {
+	phoneOrEmail, err := s.normalizeIdentity(req.PhoneOrEmail)
+	if err != nil {
+		return nil, err
+	}
+	req.PhoneOrEmail = phoneOrEmail
+
+	found, err := s.repo.GetVerificationInfo(ctx, req.PhoneOrEmail, req.Purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	if found == nil {
+		return &pb.RevokeVerificationResponse{}, nil
+	}
+
+	err = s.repo.DeleteVerificationInfo(ctx, req.PhoneOrEmail, req.Purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[Admin] Revoked pending verification for %s, purpose %q", req.PhoneOrEmail, req.Purpose)
+
+	return &pb.RevokeVerificationResponse{Revoked: true}, nil
+}
+
+func (s *AdminServer) ResetRateLimits(ctx context.Context, req *pb.ResetRateLimitsRequest) (*pb.ResetRateLimitsResponse, error) {
+	phoneOrEmail, err := s.normalizeIdentity(req.PhoneOrEmail)
+	if err != nil {
+		return nil, err
+	}
+	req.PhoneOrEmail = phoneOrEmail
+
+	var keys []string
+	if req.PhoneOrEmail != "" {
+		keys = append(keys, identityQuotaKey(req.PhoneOrEmail, s.cfg.CanonicalEmailDomains))
+	}
+	if req.ClientIp != "" {
+		keys = append(keys, ipQuotaKey(req.ClientIp))
+	}
+
+	if len(keys) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "phone_or_email or client_ip is required")
+	}
+
+	cleared, err := s.repo.ResetRateLimits(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	var unlocked bool
+	if req.PhoneOrEmail != "" {
+		unlocked, err = s.repo.ClearLockout(ctx, req.PhoneOrEmail)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Clients are locked out under their quota key; see
+	// ValidateVerificationCode.
+	if req.ClientIp != "" {
+		clientUnlocked, err := s.repo.ClearLockout(ctx, ipQuotaKey(req.ClientIp))
+		if err != nil {
+			return nil, err
+		}
+		unlocked = unlocked || clientUnlocked
+	}
+
+	log.Printf("[Admin] Reset %d rate limit counters for %s %s, lockout cleared: %v", cleared, req.PhoneOrEmail, req.ClientIp, unlocked)
+
+	return &pb.ResetRateLimitsResponse{Cleared: int32(cleared), LockoutCleared: unlocked}, nil
+}
+
+func (s *AdminServer) ListRecentSends(ctx context.Context, req *pb.ListRecentSendsRequest) (*pb.ListRecentSendsResponse, error) {
+	phoneOrEmail, err := s.normalizeIdentity(req.PhoneOrEmail)
+	if err != nil {
+		return nil, err
+	}
+	req.PhoneOrEmail = phoneOrEmail
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultRecentSends
+	}
+	limit = min(limit, maxRecentSends)
+
+	sends, err := s.repo.ListRecentSends(ctx, req.PhoneOrEmail, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListRecentSendsResponse{}
+	for _, send := range sends {
+		res.Sends = append(res.Sends, &pb.RecentSend{
+			PhoneOrEmail:   send.PhoneOrEmail,
+			Purpose:        send.Purpose,
+			Channel:        send.Channel,
+			SentAt:         send.SentAt.Unix(),
+			Resend:         send.Resend,
+			DeliveryFailed: send.Failed,
+			Vendor:         send.Vendor,
+			Transactional:  send.Transactional,
+		})
+	}
+
+	return res, nil
+}
+
+// maskCode keeps the last quarter of code so support staff can match it
+// against what a user reads out without learning enough to use it.
+func maskCode(code string) string {
+	visible := len(code) / 4
+	return strings.Repeat("*", len(code)-visible) + code[len(code)-visible:]
+}
@@ -0,0 +1,168 @@
+// Package bypass holds the fixed verification codes granted to test
+// identities, such as accounts used by app store reviewers.
+package bypass
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// Entry grants Code to the identities it matches: one exact phone number, an
+// inclusive range of phone numbers, or an email glob such as
+// "*@review.example.com".
+type Entry struct {
+	Name      string     `json:"name"`
+	Phone     string     `json:"phone,omitempty"`
+	PhoneFrom string     `json:"phone_from,omitempty"`
+	PhoneTo   string     `json:"phone_to,omitempty"`
+	Email     string     `json:"email,omitempty"`
+	Code      string     `json:"code"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type Registry struct {
+	entries []Entry
+}
+
+// Load reads a JSON array of entries from path. An empty path yields an empty
+// registry, which matches nothing.
+func Load(filePath string) (*Registry, error) {
+	if filePath == "" {
+		return &Registry{}, nil
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("parsing bypass registry: %w", err)
+	}
+
+	for i := range entries {
+		err = entries[i].normalize()
+		if err != nil {
+			return nil, fmt.Errorf("bypass entry %d (%s): %w", i, entries[i].Name, err)
+		}
+	}
+
+	return &Registry{entries: entries}, nil
+}
+
+// Len returns the number of entries, expired ones included.
+func (r *Registry) Len() int {
+	return len(r.entries)
+}
+
+// Match returns the unexpired entry that covers identity and grants code, or
+// nil.
+func (r *Registry) Match(identity, code string, now time.Time) *Entry {
+	for i := range r.entries {
+		e := &r.entries[i]
+		if e.ExpiresAt != nil && !now.Before(*e.ExpiresAt) {
+			continue
+		}
+		if !e.covers(identity) {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(e.Code), []byte(code)) == 1 {
+			return e
+		}
+	}
+	return nil
+}
+
+// Covering returns the names of the unexpired entries that cover identity,
+// whatever their code.
+func (r *Registry) Covering(identity string, now time.Time) []string {
+	var names []string
+	for i := range r.entries {
+		e := &r.entries[i]
+		if e.ExpiresAt != nil && !now.Before(*e.ExpiresAt) {
+			continue
+		}
+		if e.covers(identity) {
+			names = append(names, e.Name)
+		}
+	}
+	return names
+}
+
+func (e *Entry) normalize() error {
+	if e.Name == "" {
+		return errors.New("name is required")
+	}
+	if e.Code == "" {
+		return errors.New("code is required")
+	}
+
+	kinds := 0
+	if e.Phone != "" {
+		kinds++
+		e.Phone = phoneDigits(e.Phone)
+	}
+	if e.PhoneFrom != "" || e.PhoneTo != "" {
+		kinds++
+		e.PhoneFrom = phoneDigits(e.PhoneFrom)
+		e.PhoneTo = phoneDigits(e.PhoneTo)
+		if e.PhoneFrom == "" || len(e.PhoneFrom) != len(e.PhoneTo) || e.PhoneFrom > e.PhoneTo {
+			return errors.New("phone_from and phone_to must be an ordered range of numbers of the same length")
+		}
+	}
+	if e.Email != "" {
+		kinds++
+		e.Email = strings.ToLower(e.Email)
+		if _, err := path.Match(e.Email, ""); err != nil {
+			return fmt.Errorf("invalid email glob: %w", err)
+		}
+	}
+	if kinds != 1 {
+		return errors.New("exactly one of phone, phone_from/phone_to or email is required")
+	}
+
+	return nil
+}
+
+func (e *Entry) covers(identity string) bool {
+	if e.Email != "" {
+		if !strings.Contains(identity, "@") {
+			return false
+		}
+		ok, _ := path.Match(e.Email, strings.ToLower(identity))
+		return ok
+	}
+
+	digits := phoneDigits(identity)
+	if digits == "" {
+		return false
+	}
+	if e.Phone != "" {
+		return digits == e.Phone
+	}
+	return len(digits) == len(e.PhoneFrom) && digits >= e.PhoneFrom && digits <= e.PhoneTo
+}
+
+// phoneDigits strips formatting so "+1 (555) 010-0000" and "15550100000"
+// compare equal. Anything that is not a phone number yields "".
+func phoneDigits(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' || r == ' ' || r == '-' || r == '(' || r == ')':
+		default:
+			return ""
+		}
+	}
+	return b.String()
+}
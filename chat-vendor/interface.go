@@ -0,0 +1,7 @@
+package chat
+
+// ChatVendor delivers codes through a chat app to the account registered for
+// a phone number.
+type ChatVendor interface {
+	SendCode(phoneNumber, code string) error
+}
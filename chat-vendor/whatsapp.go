@@ -0,0 +1,114 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// WhatsAppVendor sends codes as WhatsApp authentication template messages
+// through the WhatsApp Business Cloud API.
+type WhatsAppVendor struct {
+	cfg    WhatsAppConfig
+	client *http.Client
+}
+
+type WhatsAppConfig struct {
+	AccessToken   string `envconfig:"WHATSAPP_ACCESS_TOKEN"`
+	PhoneNumberId string `envconfig:"WHATSAPP_PHONE_NUMBER_ID"`
+	// Template is an approved authentication template with a copy-code
+	// button.
+	Template string `envconfig:"WHATSAPP_TEMPLATE"`
+	Language string `envconfig:"WHATSAPP_LANGUAGE" default:"en_US"`
+	BaseURL  string `envconfig:"WHATSAPP_BASE_URL" default:"https://graph.facebook.com/v19.0"`
+}
+
+func NewWhatsAppVendor() (*WhatsAppVendor, error) {
+	var cfg WhatsAppConfig
+	err := envconfig.Process("", &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.AccessToken == "" || cfg.PhoneNumberId == "" || cfg.Template == "" {
+		return nil, errors.New("whatsapp access token, phone number id and template are required")
+	}
+
+	return &WhatsAppVendor{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+type whatsAppParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type whatsAppComponent struct {
+	Type       string              `json:"type"`
+	SubType    string              `json:"sub_type,omitempty"`
+	Index      string              `json:"index,omitempty"`
+	Parameters []whatsAppParameter `json:"parameters"`
+}
+
+type whatsAppMessage struct {
+	MessagingProduct string `json:"messaging_product"`
+	To               string `json:"to"`
+	Type             string `json:"type"`
+	Template         struct {
+		Name     string `json:"name"`
+		Language struct {
+			Code string `json:"code"`
+		} `json:"language"`
+		Components []whatsAppComponent `json:"components"`
+	} `json:"template"`
+}
+
+func (v *WhatsAppVendor) SendCode(phoneNumber, code string) error {
+	// Authentication templates take the code for both the body and the
+	// copy-code button.
+	param := []whatsAppParameter{{Type: "text", Text: code}}
+
+	msg := whatsAppMessage{MessagingProduct: "whatsapp", To: strings.TrimPrefix(phoneNumber, "+"), Type: "template"}
+	msg.Template.Name = v.cfg.Template
+	msg.Template.Language.Code = v.cfg.Language
+	msg.Template.Components = []whatsAppComponent{
+		{Type: "body", Parameters: param},
+		{Type: "button", SubType: "url", Index: "0", Parameters: param},
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error marshaling payload: %v", err)
+	}
+
+	url := fmt.Sprintf("%s/%s/messages", strings.TrimSuffix(v.cfg.BaseURL, "/"), v.cfg.PhoneNumberId)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("error creating HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+v.cfg.AccessToken)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		log.Printf("whatsapp: request failed to %s: %v", phoneNumber, err)
+		return fmt.Errorf("error sending request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		log.Printf("whatsapp: non-200 response for %s, status %s: %s", phoneNumber, resp.Status, body)
+		return fmt.Errorf("failed to send whatsapp message, HTTP status: %v", resp.Status)
+	}
+
+	log.Printf("whatsapp: code sent to %s", phoneNumber)
+	return nil
+}
@@ -1,7 +1,13 @@
 package main
 
-import "github.com/more-than-code/messaging"
+import (
+	"log"
+
+	"github.com/more-than-code/messaging"
+)
 
 func main() {
-	messaging.NewServer()
+	if err := messaging.NewServer(); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -7,6 +7,7 @@ const (
 	MsgInvalidArguments     VerificationCodeGenerationMsg = "invalid argument(s)"
 	MsgSendingTooFrequently VerificationCodeGenerationMsg = "sending too frequently"
 	MsgNeedingResending     VerificationCodeGenerationMsg = "needing resending"
+	MsgLocked               VerificationCodeGenerationMsg = "locked"
 )
 
 type VerificationCodeValidationMsg string
@@ -16,4 +17,15 @@ const (
 	MsgInvalid         VerificationCodeValidationMsg = "invalid"
 	MsgExpired         VerificationCodeValidationMsg = "expired"
 	MsgMaximumAttempts VerificationCodeValidationMsg = "maximum attempts"
+	MsgLockedOut       VerificationCodeValidationMsg = "locked"
+)
+
+type TotpValidationMsg string
+
+const (
+	MsgTotpValid       TotpValidationMsg = "valid"
+	MsgTotpInvalid     TotpValidationMsg = "invalid"
+	MsgTotpNotEnrolled TotpValidationMsg = "not enrolled"
+	MsgTotpReplayed    TotpValidationMsg = "code already used"
+	MsgTotpLocked      TotpValidationMsg = "locked"
 )
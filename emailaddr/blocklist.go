@@ -0,0 +1,77 @@
+package emailaddr
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// Blocklist holds email domains that aren't accepted, typically disposable
+// mailbox providers. A listed domain also blocks its subdomains.
+type Blocklist struct {
+	domains map[string]bool
+}
+
+// LoadBlocklist combines domains with those in filePath, one per line with
+// "#" comments. An empty path reads no file.
+func LoadBlocklist(filePath string, domains []string) (*Blocklist, error) {
+	b := &Blocklist{domains: make(map[string]bool)}
+
+	for _, d := range domains {
+		b.add(d)
+	}
+
+	if filePath == "" {
+		return b, nil
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		b.add(line)
+	}
+
+	return b, scanner.Err()
+}
+
+func (b *Blocklist) add(domain string) {
+	domain = strings.Trim(strings.TrimSpace(domain), ".")
+	if ascii, err := idna.Lookup.ToASCII(domain); err == nil {
+		domain = ascii
+	}
+
+	domain = strings.ToLower(domain)
+	if domain != "" {
+		b.domains[domain] = true
+	}
+}
+
+// Len returns the number of listed domains.
+func (b *Blocklist) Len() int {
+	return len(b.domains)
+}
+
+// Blocked reports whether domain or one of its parent domains is listed.
+func (b *Blocklist) Blocked(domain string) bool {
+	domain = strings.ToLower(domain)
+
+	for {
+		if b.domains[domain] {
+			return true
+		}
+
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok {
+			return false
+		}
+		domain = parent
+	}
+}
@@ -0,0 +1,77 @@
+// Package emailaddr parses email addresses per RFC 5322 and normalizes them
+// so every spelling of an address maps to the same identity.
+package emailaddr
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+const (
+	maxLocalLength   = 64
+	maxAddressLength = 254
+)
+
+var ErrInvalid = errors.New("invalid email address")
+
+type Address struct {
+	Local string
+	// Domain is lowercase ASCII; internationalized domains are punycoded.
+	Domain string
+}
+
+// Parse accepts a bare address such as "user@example.com". Display names
+// and angle brackets are rejected.
+func Parse(raw string) (*Address, error) {
+	raw = strings.TrimSpace(raw)
+
+	parsed, err := mail.ParseAddress(raw)
+	if err != nil || parsed.Name != "" || strings.ContainsAny(raw, "<>") {
+		return nil, ErrInvalid
+	}
+
+	at := strings.LastIndex(parsed.Address, "@")
+	local, domain := parsed.Address[:at], parsed.Address[at+1:]
+
+	domain, err = idna.Lookup.ToASCII(domain)
+	if err != nil || !strings.Contains(domain, ".") {
+		return nil, ErrInvalid
+	}
+
+	addr := &Address{Local: local, Domain: strings.ToLower(domain)}
+	if len(addr.Local) > maxLocalLength || len(addr.String()) > maxAddressLength {
+		return nil, ErrInvalid
+	}
+
+	// Quoted local parts like "john doe" come back unquoted; mail vendors
+	// don't take them reliably, so only accept addresses that stay valid.
+	if _, err = mail.ParseAddress(addr.String()); err != nil {
+		return nil, ErrInvalid
+	}
+
+	return addr, nil
+}
+
+func (a *Address) String() string {
+	return a.Local + "@" + a.Domain
+}
+
+// Canonical drops the "+tag" suffix and dots from the local part when Domain
+// is one of domains, which deliver all such variants to the same mailbox.
+// Other addresses are only lowercased.
+func (a *Address) Canonical(domains []string) string {
+	local := strings.ToLower(a.Local)
+
+	for _, d := range domains {
+		if strings.EqualFold(a.Domain, d) {
+			local, _, _ = strings.Cut(local, "+")
+			local = strings.ReplaceAll(local, ".", "")
+			break
+		}
+	}
+
+	return local + "@" + a.Domain
+}
@@ -0,0 +1,85 @@
+package messaging
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// CodeGenerator produces verification codes of the given length using only
+// characters from alphabet.
+type CodeGenerator interface {
+	Generate(length int, alphabet string) (string, error)
+}
+
+// RandomCodeGenerator draws every character from crypto/rand.
+type RandomCodeGenerator struct{}
+
+func (RandomCodeGenerator) Generate(length int, alphabet string) (string, error) {
+	if alphabet == "" {
+		return "", errors.New("code alphabet is empty")
+	}
+
+	max := big.NewInt(int64(len(alphabet)))
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = alphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}
+
+// FixedCodeGenerator repeats Code until the requested length is reached, so
+// tests can predict the code without reading Redis. It must never be used
+// outside development.
+type FixedCodeGenerator struct {
+	Code string
+}
+
+func (g FixedCodeGenerator) Generate(length int, alphabet string) (string, error) {
+	if g.Code == "" {
+		return "", errors.New("fixed code is empty")
+	}
+
+	code := make([]byte, length)
+	for i := range code {
+		c := g.Code[i%len(g.Code)]
+		if strings.IndexByte(alphabet, c) < 0 {
+			return "", fmt.Errorf("fixed code character %q not in alphabet", c)
+		}
+		code[i] = c
+	}
+
+	return string(code), nil
+}
+
+func newCodeGenerator(cfg *ServerConfig) (CodeGenerator, error) {
+	switch strings.ToUpper(cfg.CodeGenerator) {
+	case "", "RANDOM":
+		return RandomCodeGenerator{}, nil
+	case "FIXED":
+		if !cfg.IsDev {
+			return nil, errors.New("FIXED code generator is only allowed when IS_DEV is set")
+		}
+		return FixedCodeGenerator{Code: cfg.FixedCode}, nil
+	default:
+		return nil, fmt.Errorf("unsupported code generator: %s", cfg.CodeGenerator)
+	}
+}
+
+// newVerificationToken returns a URL-safe token with 256 bits of entropy for
+// link verification.
+func newVerificationToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -21,9 +21,14 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Messaging_GenerateVerificationCode_FullMethodName = "/pb.Messaging/GenerateVerificationCode"
-	Messaging_ValidateVerificationCode_FullMethodName = "/pb.Messaging/ValidateVerificationCode"
-	Messaging_SendEmailWithAttachment_FullMethodName  = "/pb.Messaging/SendEmailWithAttachment"
+	Messaging_GenerateVerificationCode_FullMethodName  = "/pb.Messaging/GenerateVerificationCode"
+	Messaging_ValidateVerificationCode_FullMethodName  = "/pb.Messaging/ValidateVerificationCode"
+	Messaging_ValidateVerificationToken_FullMethodName = "/pb.Messaging/ValidateVerificationToken"
+	Messaging_EnrollTotp_FullMethodName                = "/pb.Messaging/EnrollTotp"
+	Messaging_ConfirmTotpEnrollment_FullMethodName     = "/pb.Messaging/ConfirmTotpEnrollment"
+	Messaging_ValidateTotp_FullMethodName              = "/pb.Messaging/ValidateTotp"
+	Messaging_SendEmailWithAttachment_FullMethodName   = "/pb.Messaging/SendEmailWithAttachment"
+	Messaging_SendSms_FullMethodName                   = "/pb.Messaging/SendSms"
 )
 
 // MessagingClient is the client API for Messaging service.
@@ -32,7 +37,12 @@ const (
 type MessagingClient interface {
 	GenerateVerificationCode(ctx context.Context, in *GenerateVerificationCodeRequest, opts ...grpc.CallOption) (*GenerateVerificationCodeResponse, error)
 	ValidateVerificationCode(ctx context.Context, in *ValidateVerificationCodeRequest, opts ...grpc.CallOption) (*ValidateVerificationCodeResponse, error)
+	ValidateVerificationToken(ctx context.Context, in *ValidateVerificationTokenRequest, opts ...grpc.CallOption) (*ValidateVerificationTokenResponse, error)
+	EnrollTotp(ctx context.Context, in *EnrollTotpRequest, opts ...grpc.CallOption) (*EnrollTotpResponse, error)
+	ConfirmTotpEnrollment(ctx context.Context, in *ConfirmTotpEnrollmentRequest, opts ...grpc.CallOption) (*ConfirmTotpEnrollmentResponse, error)
+	ValidateTotp(ctx context.Context, in *ValidateTotpRequest, opts ...grpc.CallOption) (*ValidateTotpResponse, error)
 	SendEmailWithAttachment(ctx context.Context, in *SendEmailWithAttachmentRequest, opts ...grpc.CallOption) (*SendEmailWithAttachmentResponse, error)
+	SendSms(ctx context.Context, in *SendSmsRequest, opts ...grpc.CallOption) (*SendSmsResponse, error)
 }
 
 type messagingClient struct {
@@ -61,6 +71,42 @@ func (c *messagingClient) ValidateVerificationCode(ctx context.Context, in *Vali
 	return out, nil
 }
 
+func (c *messagingClient) ValidateVerificationToken(ctx context.Context, in *ValidateVerificationTokenRequest, opts ...grpc.CallOption) (*ValidateVerificationTokenResponse, error) {
+	out := new(ValidateVerificationTokenResponse)
+	err := c.cc.Invoke(ctx, Messaging_ValidateVerificationToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messagingClient) EnrollTotp(ctx context.Context, in *EnrollTotpRequest, opts ...grpc.CallOption) (*EnrollTotpResponse, error) {
+	out := new(EnrollTotpResponse)
+	err := c.cc.Invoke(ctx, Messaging_EnrollTotp_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messagingClient) ConfirmTotpEnrollment(ctx context.Context, in *ConfirmTotpEnrollmentRequest, opts ...grpc.CallOption) (*ConfirmTotpEnrollmentResponse, error) {
+	out := new(ConfirmTotpEnrollmentResponse)
+	err := c.cc.Invoke(ctx, Messaging_ConfirmTotpEnrollment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messagingClient) ValidateTotp(ctx context.Context, in *ValidateTotpRequest, opts ...grpc.CallOption) (*ValidateTotpResponse, error) {
+	out := new(ValidateTotpResponse)
+	err := c.cc.Invoke(ctx, Messaging_ValidateTotp_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *messagingClient) SendEmailWithAttachment(ctx context.Context, in *SendEmailWithAttachmentRequest, opts ...grpc.CallOption) (*SendEmailWithAttachmentResponse, error) {
 	out := new(SendEmailWithAttachmentResponse)
 	err := c.cc.Invoke(ctx, Messaging_SendEmailWithAttachment_FullMethodName, in, out, opts...)
@@ -70,13 +116,27 @@ func (c *messagingClient) SendEmailWithAttachment(ctx context.Context, in *SendE
 	return out, nil
 }
 
+func (c *messagingClient) SendSms(ctx context.Context, in *SendSmsRequest, opts ...grpc.CallOption) (*SendSmsResponse, error) {
+	out := new(SendSmsResponse)
+	err := c.cc.Invoke(ctx, Messaging_SendSms_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MessagingServer is the server API for Messaging service.
 // All implementations must embed UnimplementedMessagingServer
 // for forward compatibility
 type MessagingServer interface {
 	GenerateVerificationCode(context.Context, *GenerateVerificationCodeRequest) (*GenerateVerificationCodeResponse, error)
 	ValidateVerificationCode(context.Context, *ValidateVerificationCodeRequest) (*ValidateVerificationCodeResponse, error)
+	ValidateVerificationToken(context.Context, *ValidateVerificationTokenRequest) (*ValidateVerificationTokenResponse, error)
+	EnrollTotp(context.Context, *EnrollTotpRequest) (*EnrollTotpResponse, error)
+	ConfirmTotpEnrollment(context.Context, *ConfirmTotpEnrollmentRequest) (*ConfirmTotpEnrollmentResponse, error)
+	ValidateTotp(context.Context, *ValidateTotpRequest) (*ValidateTotpResponse, error)
 	SendEmailWithAttachment(context.Context, *SendEmailWithAttachmentRequest) (*SendEmailWithAttachmentResponse, error)
+	SendSms(context.Context, *SendSmsRequest) (*SendSmsResponse, error)
 	mustEmbedUnimplementedMessagingServer()
 }
 
@@ -90,9 +150,24 @@ func (UnimplementedMessagingServer) GenerateVerificationCode(context.Context, *G
 func (UnimplementedMessagingServer) ValidateVerificationCode(context.Context, *ValidateVerificationCodeRequest) (*ValidateVerificationCodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateVerificationCode not implemented")
 }
+func (UnimplementedMessagingServer) ValidateVerificationToken(context.Context, *ValidateVerificationTokenRequest) (*ValidateVerificationTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateVerificationToken not implemented")
+}
+func (UnimplementedMessagingServer) EnrollTotp(context.Context, *EnrollTotpRequest) (*EnrollTotpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTotp not implemented")
+}
+func (UnimplementedMessagingServer) ConfirmTotpEnrollment(context.Context, *ConfirmTotpEnrollmentRequest) (*ConfirmTotpEnrollmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTotpEnrollment not implemented")
+}
+func (UnimplementedMessagingServer) ValidateTotp(context.Context, *ValidateTotpRequest) (*ValidateTotpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateTotp not implemented")
+}
 func (UnimplementedMessagingServer) SendEmailWithAttachment(context.Context, *SendEmailWithAttachmentRequest) (*SendEmailWithAttachmentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendEmailWithAttachment not implemented")
 }
+func (UnimplementedMessagingServer) SendSms(context.Context, *SendSmsRequest) (*SendSmsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendSms not implemented")
+}
 func (UnimplementedMessagingServer) mustEmbedUnimplementedMessagingServer() {}
 
 // UnsafeMessagingServer may be embedded to opt out of forward compatibility for this service.
@@ -142,6 +217,78 @@ func _Messaging_ValidateVerificationCode_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Messaging_ValidateVerificationToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateVerificationTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessagingServer).ValidateVerificationToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Messaging_ValidateVerificationToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessagingServer).ValidateVerificationToken(ctx, req.(*ValidateVerificationTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Messaging_EnrollTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollTotpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessagingServer).EnrollTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Messaging_EnrollTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessagingServer).EnrollTotp(ctx, req.(*EnrollTotpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Messaging_ConfirmTotpEnrollment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTotpEnrollmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessagingServer).ConfirmTotpEnrollment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Messaging_ConfirmTotpEnrollment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessagingServer).ConfirmTotpEnrollment(ctx, req.(*ConfirmTotpEnrollmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Messaging_ValidateTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateTotpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessagingServer).ValidateTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Messaging_ValidateTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessagingServer).ValidateTotp(ctx, req.(*ValidateTotpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Messaging_SendEmailWithAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SendEmailWithAttachmentRequest)
 	if err := dec(in); err != nil {
@@ -160,6 +307,24 @@ func _Messaging_SendEmailWithAttachment_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Messaging_SendSms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendSmsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessagingServer).SendSms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Messaging_SendSms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessagingServer).SendSms(ctx, req.(*SendSmsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Messaging_ServiceDesc is the grpc.ServiceDesc for Messaging service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -175,10 +340,231 @@ var Messaging_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ValidateVerificationCode",
 			Handler:    _Messaging_ValidateVerificationCode_Handler,
 		},
+		{
+			MethodName: "ValidateVerificationToken",
+			Handler:    _Messaging_ValidateVerificationToken_Handler,
+		},
+		{
+			MethodName: "EnrollTotp",
+			Handler:    _Messaging_EnrollTotp_Handler,
+		},
+		{
+			MethodName: "ConfirmTotpEnrollment",
+			Handler:    _Messaging_ConfirmTotpEnrollment_Handler,
+		},
+		{
+			MethodName: "ValidateTotp",
+			Handler:    _Messaging_ValidateTotp_Handler,
+		},
 		{
 			MethodName: "SendEmailWithAttachment",
 			Handler:    _Messaging_SendEmailWithAttachment_Handler,
 		},
+		{
+			MethodName: "SendSms",
+			Handler:    _Messaging_SendSms_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "messaging.proto",
+}
+
+const (
+	MessagingAdmin_GetPendingVerification_FullMethodName = "/pb.MessagingAdmin/GetPendingVerification"
+	MessagingAdmin_RevokeVerification_FullMethodName     = "/pb.MessagingAdmin/RevokeVerification"
+	MessagingAdmin_ResetRateLimits_FullMethodName        = "/pb.MessagingAdmin/ResetRateLimits"
+	MessagingAdmin_ListRecentSends_FullMethodName        = "/pb.MessagingAdmin/ListRecentSends"
+)
+
+// MessagingAdminClient is the client API for MessagingAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MessagingAdminClient interface {
+	GetPendingVerification(ctx context.Context, in *GetPendingVerificationRequest, opts ...grpc.CallOption) (*GetPendingVerificationResponse, error)
+	RevokeVerification(ctx context.Context, in *RevokeVerificationRequest, opts ...grpc.CallOption) (*RevokeVerificationResponse, error)
+	ResetRateLimits(ctx context.Context, in *ResetRateLimitsRequest, opts ...grpc.CallOption) (*ResetRateLimitsResponse, error)
+	ListRecentSends(ctx context.Context, in *ListRecentSendsRequest, opts ...grpc.CallOption) (*ListRecentSendsResponse, error)
+}
+
+type messagingAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMessagingAdminClient(cc grpc.ClientConnInterface) MessagingAdminClient {
+	return &messagingAdminClient{cc}
+}
+
+func (c *messagingAdminClient) GetPendingVerification(ctx context.Context, in *GetPendingVerificationRequest, opts ...grpc.CallOption) (*GetPendingVerificationResponse, error) {
+	out := new(GetPendingVerificationResponse)
+	err := c.cc.Invoke(ctx, MessagingAdmin_GetPendingVerification_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messagingAdminClient) RevokeVerification(ctx context.Context, in *RevokeVerificationRequest, opts ...grpc.CallOption) (*RevokeVerificationResponse, error) {
+	out := new(RevokeVerificationResponse)
+	err := c.cc.Invoke(ctx, MessagingAdmin_RevokeVerification_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messagingAdminClient) ResetRateLimits(ctx context.Context, in *ResetRateLimitsRequest, opts ...grpc.CallOption) (*ResetRateLimitsResponse, error) {
+	out := new(ResetRateLimitsResponse)
+	err := c.cc.Invoke(ctx, MessagingAdmin_ResetRateLimits_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messagingAdminClient) ListRecentSends(ctx context.Context, in *ListRecentSendsRequest, opts ...grpc.CallOption) (*ListRecentSendsResponse, error) {
+	out := new(ListRecentSendsResponse)
+	err := c.cc.Invoke(ctx, MessagingAdmin_ListRecentSends_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MessagingAdminServer is the server API for MessagingAdmin service.
+// All implementations must embed UnimplementedMessagingAdminServer
+// for forward compatibility
+type MessagingAdminServer interface {
+	GetPendingVerification(context.Context, *GetPendingVerificationRequest) (*GetPendingVerificationResponse, error)
+	RevokeVerification(context.Context, *RevokeVerificationRequest) (*RevokeVerificationResponse, error)
+	ResetRateLimits(context.Context, *ResetRateLimitsRequest) (*ResetRateLimitsResponse, error)
+	ListRecentSends(context.Context, *ListRecentSendsRequest) (*ListRecentSendsResponse, error)
+	mustEmbedUnimplementedMessagingAdminServer()
+}
+
+// UnimplementedMessagingAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedMessagingAdminServer struct {
+}
+
+func (UnimplementedMessagingAdminServer) GetPendingVerification(context.Context, *GetPendingVerificationRequest) (*GetPendingVerificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPendingVerification not implemented")
+}
+func (UnimplementedMessagingAdminServer) RevokeVerification(context.Context, *RevokeVerificationRequest) (*RevokeVerificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeVerification not implemented")
+}
+func (UnimplementedMessagingAdminServer) ResetRateLimits(context.Context, *ResetRateLimitsRequest) (*ResetRateLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetRateLimits not implemented")
+}
+func (UnimplementedMessagingAdminServer) ListRecentSends(context.Context, *ListRecentSendsRequest) (*ListRecentSendsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecentSends not implemented")
+}
+func (UnimplementedMessagingAdminServer) mustEmbedUnimplementedMessagingAdminServer() {}
+
+// UnsafeMessagingAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MessagingAdminServer will
+// result in compilation errors.
+type UnsafeMessagingAdminServer interface {
+	mustEmbedUnimplementedMessagingAdminServer()
+}
+
+func RegisterMessagingAdminServer(s grpc.ServiceRegistrar, srv MessagingAdminServer) {
+	s.RegisterService(&MessagingAdmin_ServiceDesc, srv)
+}
+
+func _MessagingAdmin_GetPendingVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPendingVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessagingAdminServer).GetPendingVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessagingAdmin_GetPendingVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessagingAdminServer).GetPendingVerification(ctx, req.(*GetPendingVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessagingAdmin_RevokeVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessagingAdminServer).RevokeVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessagingAdmin_RevokeVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessagingAdminServer).RevokeVerification(ctx, req.(*RevokeVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessagingAdmin_ResetRateLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetRateLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessagingAdminServer).ResetRateLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessagingAdmin_ResetRateLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessagingAdminServer).ResetRateLimits(ctx, req.(*ResetRateLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessagingAdmin_ListRecentSends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecentSendsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessagingAdminServer).ListRecentSends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessagingAdmin_ListRecentSends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessagingAdminServer).ListRecentSends(ctx, req.(*ListRecentSendsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MessagingAdmin_ServiceDesc is the grpc.ServiceDesc for MessagingAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MessagingAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.MessagingAdmin",
+	HandlerType: (*MessagingAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPendingVerification",
+			Handler:    _MessagingAdmin_GetPendingVerification_Handler,
+		},
+		{
+			MethodName: "RevokeVerification",
+			Handler:    _MessagingAdmin_RevokeVerification_Handler,
+		},
+		{
+			MethodName: "ResetRateLimits",
+			Handler:    _MessagingAdmin_ResetRateLimits_Handler,
+		},
+		{
+			MethodName: "ListRecentSends",
+			Handler:    _MessagingAdmin_ListRecentSends_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "messaging.proto",
@@ -0,0 +1,62 @@
+// Package phone parses user-entered phone numbers and normalizes them to
+// E.164, so every spelling of a number maps to the same identity.
+package phone
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ttacon/libphonenumber"
+)
+
+var ErrInvalid = errors.New("invalid phone number")
+
+type Number struct {
+	// E164 is the normalized form, e.g. "+8613800138000".
+	E164 string
+	// CountryCode is the country calling code, e.g. 86.
+	CountryCode int
+	// Region is the ISO 3166-1 alpha-2 region, e.g. "CN".
+	Region string
+}
+
+// Parse accepts international numbers with a leading "+" or international
+// prefix, and national numbers of defaultRegion. It returns ErrInvalid for
+// anything that isn't a valid number.
+func Parse(raw, defaultRegion string) (*Number, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, ErrInvalid
+	}
+
+	num, err := libphonenumber.Parse(raw, strings.ToUpper(defaultRegion))
+	if err != nil || !libphonenumber.IsValidNumber(num) {
+		return nil, ErrInvalid
+	}
+
+	return &Number{
+		E164:        libphonenumber.Format(num, libphonenumber.E164),
+		CountryCode: int(num.GetCountryCode()),
+		Region:      libphonenumber.GetRegionCodeForNumber(num),
+	}, nil
+}
+
+// CountryCode returns the country calling code of an E.164 number, or 0 if
+// it can't be parsed.
+func CountryCode(e164 string) int {
+	num, err := libphonenumber.Parse(e164, "")
+	if err != nil {
+		return 0
+	}
+	return int(num.GetCountryCode())
+}
+
+// CallingCode is CountryCode as a string, or "" if it can't be parsed.
+func CallingCode(e164 string) string {
+	cc := CountryCode(e164)
+	if cc == 0 {
+		return ""
+	}
+	return strconv.Itoa(cc)
+}
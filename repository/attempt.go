@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type AttemptResult int
+
+const (
+	AttemptValid AttemptResult = iota
+	AttemptInvalid
+	AttemptMaximumReached
+	// AttemptExpired means the record expired, was consumed or was replaced
+	// by a newer code between reading it and recording the attempt.
+	AttemptExpired
+)
+
+// recordAttemptScript consumes or charges a pending verification in one step,
+// so concurrent guesses can neither share an attempt nor reuse a valid code.
+// KEYS[1] is the verification key; ARGV is the salt the caller compared
+// against, whether it matched, and the maximum number of attempts.
+var recordAttemptScript = redis.NewScript(`
+local raw = redis.call('GET', KEYS[1])
+if not raw then
+	return {3, 0}
+end
+local info = cjson.decode(raw)
+if (info['Salt'] or '') ~= ARGV[1] then
+	return {3, 0}
+end
+local attempt = tonumber(info['Attempt'] or 0)
+if ARGV[2] == '1' then
+	redis.call('DEL', KEYS[1])
+	return {0, attempt}
+end
+if attempt >= tonumber(ARGV[3]) then
+	redis.call('DEL', KEYS[1])
+	return {2, attempt}
+end
+attempt = attempt + 1
+info['Attempt'] = attempt
+redis.call('SET', KEYS[1], cjson.encode(info), 'KEEPTTL')
+return {1, attempt}
+`)
+
+// RecordVerificationAttempt applies the outcome of comparing a guess against
+// info, which must have been read from the same key. A match deletes the
+// record. A mismatch increments Attempt, or deletes the record once
+// maxAttempts mismatches have already been counted. It returns the result and
+// the attempt count after the guess.
+func (r *Repository) RecordVerificationAttempt(ctx context.Context, phoneOrEmail, purpose string, info *VerificationInfo, matched bool, maxAttempts int) (AttemptResult, int, error) {
+	matchedArg := "0"
+	if matched {
+		matchedArg = "1"
+	}
+
+	res, err := recordAttemptScript.Run(ctx, r.redisClient, []string{verificationKey(phoneOrEmail, purpose)}, info.Salt, matchedArg, maxAttempts).Int64Slice()
+	if err != nil {
+		return AttemptExpired, 0, err
+	}
+
+	return AttemptResult(res[0]), int(res[1]), nil
+}
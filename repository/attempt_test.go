@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRepository(t *testing.T) *Repository {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return &Repository{redisClient: client, cfg: Config{CodeSecret: "test-secret"}}
+}
+
+func TestRecordVerificationAttemptParallelGuesses(t *testing.T) {
+	const maxAttempts = 5
+	const guesses = 50
+
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	err := repo.SetVerificationInfo(ctx, "+8613800138000", "login", &VerificationInfo{Code: "123456", ExpiresAt: time.Now().Add(time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := repo.GetVerificationInfo(ctx, "+8613800138000", "login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.MatchVerificationCode(info, "000000") {
+		t.Fatal("wrong guess matched")
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan AttemptResult, guesses)
+	for i := 0; i < guesses; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, _, err := repo.RecordVerificationAttempt(ctx, "+8613800138000", "login", info, false, maxAttempts)
+			if err != nil {
+				t.Error(err)
+			}
+			results <- result
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	counts := make(map[AttemptResult]int)
+	for result := range results {
+		counts[result]++
+	}
+
+	if counts[AttemptInvalid] != maxAttempts {
+		t.Errorf("got %d invalid results, want %d", counts[AttemptInvalid], maxAttempts)
+	}
+	if counts[AttemptMaximumReached] != 1 {
+		t.Errorf("got %d maximum-reached results, want 1", counts[AttemptMaximumReached])
+	}
+	if counts[AttemptExpired] != guesses-maxAttempts-1 {
+		t.Errorf("got %d expired results, want %d", counts[AttemptExpired], guesses-maxAttempts-1)
+	}
+	if counts[AttemptValid] != 0 {
+		t.Errorf("got %d valid results, want 0", counts[AttemptValid])
+	}
+
+	// Once the record is gone a late guess, even a right one, finds nothing.
+	result, _, err := repo.RecordVerificationAttempt(ctx, "+8613800138000", "login", info, true, maxAttempts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != AttemptExpired {
+		t.Errorf("got %v after exhaustion, want AttemptExpired", result)
+	}
+}
+
+func TestRecordVerificationAttemptValidConsumes(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	err := repo.SetVerificationInfo(ctx, "a@example.com", "", &VerificationInfo{Code: "123456", ExpiresAt: time.Now().Add(time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := repo.GetVerificationInfo(ctx, "a@example.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !repo.MatchVerificationCode(info, "123456") {
+		t.Fatal("right guess didn't match")
+	}
+
+	for i, want := range []AttemptResult{AttemptValid, AttemptExpired} {
+		result, _, err := repo.RecordVerificationAttempt(ctx, "a@example.com", "", info, true, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != want {
+			t.Errorf("guess %d: got %v, want %v", i, result, want)
+		}
+	}
+}
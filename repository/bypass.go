@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// BypassUse is an audit record of a bypass code being accepted.
+type BypassUse struct {
+	Entry    string
+	Identity string
+	Purpose  string
+	At       time.Time
+}
+
+const (
+	bypassAuditKey = "bypass:audit"
+	// bypassAuditSize caps the audit list; older uses are dropped.
+	bypassAuditSize = 10000
+)
+
+func (r *Repository) RecordBypassUse(ctx context.Context, use *BypassUse) error {
+	data, err := json.Marshal(use)
+	if err != nil {
+		return err
+	}
+
+	pipe := r.redisClient.TxPipeline()
+	pipe.LPush(ctx, bypassAuditKey, string(data))
+	pipe.LTrim(ctx, bypassAuditKey, 0, bypassAuditSize-1)
+	_, err = pipe.Exec(ctx)
+	return err
+}
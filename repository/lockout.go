@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func lockoutKey(identity string) string {
+	return "lockout:" + strings.ToLower(identity)
+}
+
+func lockoutStrikesKey(identity string) string {
+	return "lockout-strikes:" + strings.ToLower(identity)
+}
+
+func failedAttemptsKey(scope, identity string) string {
+	return "failed-attempts:" + scope + ":" + strings.ToLower(identity)
+}
+
+// lockIdentityScript counts a strike and locks for the duration matching the
+// strike count, capped at the last one. ARGV holds the reset window followed
+// by the durations, all in milliseconds. Strikes are forgotten once the
+// identity goes the reset window past its last lockout without a new one.
+var lockIdentityScript = redis.NewScript(`
+local strikes = redis.call('INCR', KEYS[2])
+local durations = #ARGV - 1
+local level = math.min(strikes, durations)
+local duration = tonumber(ARGV[level + 1])
+redis.call('SET', KEYS[1], level, 'PX', duration)
+redis.call('PEXPIRE', KEYS[2], duration + tonumber(ARGV[1]))
+return duration
+`)
+
+// LockIdentity locks identity out of generating and validating codes. Each
+// lockout within resetAfter of the previous one uses the next of durations.
+func (r *Repository) LockIdentity(ctx context.Context, identity string, durations []time.Duration, resetAfter time.Duration) (time.Duration, error) {
+	if len(durations) == 0 {
+		return 0, nil
+	}
+
+	args := []interface{}{resetAfter.Milliseconds()}
+	for _, d := range durations {
+		args = append(args, d.Milliseconds())
+	}
+
+	ms, err := lockIdentityScript.Run(ctx, r.redisClient, []string{lockoutKey(identity), lockoutStrikesKey(identity)}, args...).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// LockoutRemaining returns how long identity stays locked, or 0.
+func (r *Repository) LockoutRemaining(ctx context.Context, identity string) (time.Duration, error) {
+	ttl, err := r.redisClient.PTTL(ctx, lockoutKey(identity)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// countFailedAttemptScript increments a counter whose window starts at the
+// first failure. ARGV[1] is the window in milliseconds.
+var countFailedAttemptScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// RecordFailedAttempt counts a wrong guess by identity that no pending code
+// keeps track of, e.g. a TOTP code, and returns how many were counted in
+// scope within window of the first.
+func (r *Repository) RecordFailedAttempt(ctx context.Context, scope, identity string, window time.Duration) (int, error) {
+	count, err := countFailedAttemptScript.Run(ctx, r.redisClient, []string{failedAttemptsKey(scope, identity)}, window.Milliseconds()).Int()
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ClearFailedAttempts forgets the wrong guesses counted for identity in scope.
+func (r *Repository) ClearFailedAttempts(ctx context.Context, scope, identity string) error {
+	return r.redisClient.Del(ctx, failedAttemptsKey(scope, identity)).Err()
+}
+
+// ClearLockout lifts a lockout and forgets past strikes. It reports whether
+// identity was locked.
+func (r *Repository) ClearLockout(ctx context.Context, identity string) (bool, error) {
+	locked, err := r.redisClient.Exists(ctx, lockoutKey(identity)).Result()
+	if err != nil {
+		return false, err
+	}
+
+	err = r.redisClient.Del(ctx, lockoutKey(identity), lockoutStrikesKey(identity)).Err()
+	if err != nil {
+		return false, err
+	}
+
+	return locked == 1, nil
+}
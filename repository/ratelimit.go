@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimit allows at most Limit events in any sliding Window.
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// RateLimitScope applies a set of tiers to the events counted under Key.
+type RateLimitScope struct {
+	Key    string
+	Limits []RateLimit
+}
+
+// ParseRateLimits parses comma-separated tiers such as "1/1m,5/1h,10/24h".
+func ParseRateLimits(s string) ([]RateLimit, error) {
+	var limits []RateLimit
+	for _, tier := range strings.Split(s, ",") {
+		tier = strings.TrimSpace(tier)
+		if tier == "" {
+			continue
+		}
+
+		count, window, ok := strings.Cut(tier, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q, want <count>/<window>", tier)
+		}
+
+		limit, err := strconv.Atoi(count)
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("invalid rate limit count in %q", tier)
+		}
+
+		d, err := time.ParseDuration(window)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid rate limit window in %q", tier)
+		}
+
+		limits = append(limits, RateLimit{Limit: limit, Window: d})
+	}
+
+	return limits, nil
+}
+
+// consumeRateLimitsScript keeps one sorted set of event timestamps per key.
+// ARGV holds the current time and a unique member, then for every key the
+// number of tiers followed by limit/window pairs, all in milliseconds. It
+// returns 0 after recording the event everywhere, or the milliseconds until
+// every tier would allow it, in which case nothing is recorded. An empty
+// member only checks.
+var consumeRateLimitsScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local member = ARGV[2]
+local idx = 3
+local wait = 0
+local retention = {}
+for i, key in ipairs(KEYS) do
+	local tiers = tonumber(ARGV[idx])
+	idx = idx + 1
+	local longest = 0
+	for t = 1, tiers do
+		local limit = tonumber(ARGV[idx])
+		local window = tonumber(ARGV[idx + 1])
+		idx = idx + 2
+		if window > longest then
+			longest = window
+		end
+		local count = redis.call('ZCOUNT', key, '(' .. (now - window), '+inf')
+		if count >= limit then
+			local oldest = redis.call('ZRANGEBYSCORE', key, '(' .. (now - window), '+inf', 'WITHSCORES', 'LIMIT', count - limit, 1)
+			local retry = tonumber(oldest[2]) + window - now
+			if retry > wait then
+				wait = retry
+			end
+		end
+	end
+	retention[i] = longest
+end
+if wait > 0 or member == '' then
+	return wait
+end
+for i, key in ipairs(KEYS) do
+	redis.call('ZREMRANGEBYSCORE', key, '-inf', '(' .. (now - retention[i]))
+	redis.call('ZADD', key, now, member)
+	redis.call('PEXPIRE', key, retention[i])
+end
+return 0
+`)
+
+// ConsumeRateLimits records one event against every scope if all of their
+// tiers allow it. Otherwise it records nothing and returns how long the
+// caller has to wait.
+func (r *Repository) ConsumeRateLimits(ctx context.Context, scopes []RateLimitScope) (bool, time.Duration, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return false, 0, err
+	}
+
+	now := time.Now().UnixMilli()
+	wait, err := r.runRateLimits(ctx, scopes, now, strconv.FormatInt(now, 10)+"-"+hex.EncodeToString(suffix))
+	if err != nil {
+		return false, 0, err
+	}
+
+	return wait == 0, wait, nil
+}
+
+// RateLimitWait returns how long until every scope would allow another
+// event, without recording one.
+func (r *Repository) RateLimitWait(ctx context.Context, scopes []RateLimitScope) (time.Duration, error) {
+	return r.runRateLimits(ctx, scopes, time.Now().UnixMilli(), "")
+}
+
+func (r *Repository) runRateLimits(ctx context.Context, scopes []RateLimitScope, now int64, member string) (time.Duration, error) {
+	var keys []string
+	args := []interface{}{now, member}
+
+	for _, scope := range scopes {
+		if len(scope.Limits) == 0 {
+			continue
+		}
+		keys = append(keys, rateLimitKey(scope.Key))
+		args = append(args, len(scope.Limits))
+		for _, l := range scope.Limits {
+			args = append(args, l.Limit, l.Window.Milliseconds())
+		}
+	}
+
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	wait, err := consumeRateLimitsScript.Run(ctx, r.redisClient, keys, args...).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+// ResetRateLimits forgets every event counted under keys and returns how many
+// of them existed.
+func (r *Repository) ResetRateLimits(ctx context.Context, keys ...string) (int64, error) {
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = rateLimitKey(key)
+	}
+	return r.redisClient.Del(ctx, redisKeys...).Result()
+}
+
+func rateLimitKey(key string) string {
+	return "ratelimit:" + strings.ToLower(key)
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+// seal encrypts values that must be recovered later, unlike codes which are
+// only ever compared and can be hashed. The key is derived from CodeSecret.
+func (r *Repository) seal(plaintext []byte) (string, error) {
+	aead, err := r.sealCipher()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (r *Repository) open(sealed string) ([]byte, error) {
+	aead, err := r.sealCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("sealed value too short")
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+func (r *Repository) sealCipher() (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, []byte(r.cfg.CodeSecret))
+	mac.Write([]byte("seal"))
+
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// SendRecord describes one delivery of a verification code or, when
+// Transactional, of a SendSms message.
+type SendRecord struct {
+	PhoneOrEmail  string
+	Purpose       string
+	Channel       string
+	Vendor        string
+	SentAt        time.Time
+	Resend        bool
+	Failed        bool
+	Transactional bool `json:",omitempty"`
+}
+
+const (
+	recentSendsKey = "sends:recent"
+	// recentSendsSize caps the list; older sends are dropped.
+	recentSendsSize = 1000
+)
+
+func (r *Repository) RecordSend(ctx context.Context, send *SendRecord) error {
+	data, err := json.Marshal(send)
+	if err != nil {
+		return err
+	}
+
+	pipe := r.redisClient.TxPipeline()
+	pipe.LPush(ctx, recentSendsKey, string(data))
+	pipe.LTrim(ctx, recentSendsKey, 0, recentSendsSize-1)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// ListRecentSends returns up to limit sends, newest first, optionally only
+// those to phoneOrEmail.
+func (r *Repository) ListRecentSends(ctx context.Context, phoneOrEmail string, limit int) ([]SendRecord, error) {
+	items, err := r.redisClient.LRange(ctx, recentSendsKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var sends []SendRecord
+	for _, item := range items {
+		var send SendRecord
+		if err := json.Unmarshal([]byte(item), &send); err != nil {
+			return nil, err
+		}
+		if phoneOrEmail != "" && !strings.EqualFold(send.PhoneOrEmail, phoneOrEmail) {
+			continue
+		}
+		sends = append(sends, send)
+		if len(sends) == limit {
+			break
+		}
+	}
+
+	return sends, nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TokenRef points a verification token at the pending verification it was
+// issued with.
+type TokenRef struct {
+	PhoneOrEmail string
+	Purpose      string
+}
+
+// tokenKey indexes tokens by their HMAC so the raw token never reaches Redis.
+func (r *Repository) tokenKey(token string) string {
+	mac := hmac.New(sha256.New, []byte(r.cfg.CodeSecret))
+	mac.Write([]byte("token"))
+	mac.Write([]byte{0})
+	mac.Write([]byte(token))
+	return "token:" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (r *Repository) SetVerificationToken(ctx context.Context, token string, ref *TokenRef, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return errors.New("token already expired")
+	}
+
+	data, err := json.Marshal(ref)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.Set(ctx, r.tokenKey(token), string(data), ttl).Err()
+}
+
+// ConsumeVerificationToken removes token from the index and returns what it
+// pointed at, so each token resolves at most once. It returns nil if the
+// token is unknown, expired or already used.
+func (r *Repository) ConsumeVerificationToken(ctx context.Context, token string) (*TokenRef, error) {
+	str, err := r.redisClient.GetDel(ctx, r.tokenKey(token)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ref := &TokenRef{}
+	err = json.Unmarshal([]byte(str), ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return ref, nil
+}
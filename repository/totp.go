@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TotpInfo is an authenticator-app enrollment. Secret is sealed before it is
+// written to Redis.
+type TotpInfo struct {
+	Secret       []byte `json:"-"`
+	SealedSecret string
+	Confirmed    bool
+	CreatedAt    time.Time
+}
+
+// PendingTotpTTL bounds how long an enrollment can wait for confirmation.
+const PendingTotpTTL = time.Minute * 10
+
+func totpKey(identity string, confirmed bool) string {
+	if confirmed {
+		return "totp:" + strings.ToLower(identity)
+	}
+	return "totp-pending:" + strings.ToLower(identity)
+}
+
+func totpCounterKey(identity string) string {
+	return "totp-counter:" + strings.ToLower(identity)
+}
+
+// GetTotpInfo returns the confirmed or the pending enrollment of identity,
+// or nil if there is none.
+func (r *Repository) GetTotpInfo(ctx context.Context, identity string, confirmed bool) (*TotpInfo, error) {
+	str, err := r.redisClient.Get(ctx, totpKey(identity, confirmed)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info := &TotpInfo{}
+	err = json.Unmarshal([]byte(str), info)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Secret, err = r.open(info.SealedSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// SetPendingTotpInfo stores an unconfirmed enrollment, replacing any earlier
+// pending one. A confirmed enrollment stays in effect until this one is
+// confirmed.
+func (r *Repository) SetPendingTotpInfo(ctx context.Context, identity string, info *TotpInfo) error {
+	data, err := r.marshalTotpInfo(info)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.Set(ctx, totpKey(identity, false), data, PendingTotpTTL).Err()
+}
+
+// ConfirmTotpInfo promotes the pending enrollment to the confirmed one and
+// records counter as used.
+func (r *Repository) ConfirmTotpInfo(ctx context.Context, identity string, info *TotpInfo, counter uint64) error {
+	confirmed := *info
+	confirmed.Confirmed = true
+
+	data, err := r.marshalTotpInfo(&confirmed)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, totpKey(identity, true), data, 0)
+		pipe.Set(ctx, totpCounterKey(identity), counter, 0)
+		pipe.Del(ctx, totpKey(identity, false))
+		return nil
+	})
+	return err
+}
+
+// advanceTotpCounterScript moves the last used counter forward only, which
+// makes each time step usable once even under concurrent validation.
+var advanceTotpCounterScript = redis.NewScript(`
+local last = tonumber(redis.call('GET', KEYS[1]) or '-1')
+if last >= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1])
+return 1
+`)
+
+// AdvanceTotpCounter records counter as used and reports false if it or a
+// later step was already used.
+func (r *Repository) AdvanceTotpCounter(ctx context.Context, identity string, counter uint64) (bool, error) {
+	n, err := advanceTotpCounterScript.Run(ctx, r.redisClient, []string{totpCounterKey(identity)}, counter).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *Repository) marshalTotpInfo(info *TotpInfo) (string, error) {
+	stored := *info
+
+	sealed, err := r.seal(stored.Secret)
+	if err != nil {
+		return "", err
+	}
+	stored.SealedSecret = sealed
+
+	data, err := json.Marshal(&stored)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
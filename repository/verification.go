@@ -2,7 +2,13 @@ package repository
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"log"
 	"strings"
 	"time"
@@ -14,18 +20,38 @@ import (
 type Config struct {
 	RedisUri      string `envconfig:"REDIS_URI"`
 	RedisPassword string `envconfig:"REDIS_PASSWORD"`
+	// CodeSecret keys the HMAC used to store verification codes at rest.
+	CodeSecret string `envconfig:"VERIFICATION_CODE_SECRET"`
+	// AllowPlaintextCodes keeps records written before codes were hashed
+	// valid until they expire. Disable it once the migration window is over.
+	AllowPlaintextCodes bool `envconfig:"VERIFICATION_ALLOW_PLAINTEXT_CODES" default:"true"`
 }
 
 type Repository struct {
 	redisClient *redis.Client
+	cfg         Config
 }
 
 type VerificationInfo struct {
-	Code        string
+	// Code is the plaintext code. SetVerificationInfo replaces it with
+	// CodeHash for validation and SealedCode for resending before writing,
+	// so it is only read back from legacy records.
+	Code        string `json:",omitempty"`
+	CodeHash    string `json:",omitempty"`
+	Salt        string `json:",omitempty"`
+	SealedCode  string `json:",omitempty"`
 	Attempt     int
 	LastAttempt time.Time
+	ExpiresAt   time.Time
+	// Link is set when the code is a link token rather than a typed code.
+	Link bool `json:",omitempty"`
+	// DeliveryFailed is set when the vendor rejected the last delivery.
+	DeliveryFailed bool `json:",omitempty"`
 }
 
+// defaultVerificationTTL applies to records written without ExpiresAt.
+const defaultVerificationTTL = time.Minute * 5
+
 func NewRepository() (*Repository, error) {
 	var cfg Config
 	err := envconfig.Process("", &cfg)
@@ -33,17 +59,32 @@ func NewRepository() (*Repository, error) {
 		log.Fatal(err)
 	}
 
+	if cfg.CodeSecret == "" {
+		return nil, errors.New("VERIFICATION_CODE_SECRET is required")
+	}
+
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     cfg.RedisUri,
 		Password: cfg.RedisPassword,
 		DB:       0, // use default DB
 	})
 
-	return &Repository{redisClient: redisClient}, nil
+	return &Repository{redisClient: redisClient, cfg: cfg}, nil
+}
+
+// verificationKey namespaces pending codes by purpose so a code issued for
+// one flow cannot be replayed in another. The empty purpose keeps the key
+// used before purposes existed.
+func verificationKey(phoneOrEmail, purpose string) string {
+	key := strings.ToLower(phoneOrEmail)
+	if purpose != "" {
+		key += ":" + purpose
+	}
+	return key
 }
 
-func (r *Repository) GetVerificationInfo(ctx context.Context, phoneOrEmail string) (*VerificationInfo, error) {
-	str, err := r.redisClient.Get(ctx, strings.ToLower(phoneOrEmail)).Result()
+func (r *Repository) GetVerificationInfo(ctx context.Context, phoneOrEmail, purpose string) (*VerificationInfo, error) {
+	str, err := r.redisClient.Get(ctx, verificationKey(phoneOrEmail, purpose)).Result()
 
 	var found *VerificationInfo
 
@@ -62,15 +103,122 @@ func (r *Repository) GetVerificationInfo(ctx context.Context, phoneOrEmail strin
 	return found, nil
 }
 
-func (r *Repository) SetVerificationInfo(ctx context.Context, phoneOrEmail string, info *VerificationInfo) error {
-	data, err := json.Marshal(info)
+// SetVerificationInfo stores info for phoneOrEmail and purpose until
+// info.ExpiresAt.
+// A plaintext Code is hashed with a fresh salt first; info itself is left
+// untouched.
+func (r *Repository) SetVerificationInfo(ctx context.Context, phoneOrEmail, purpose string, info *VerificationInfo) error {
+	var err error
+
+	stored := *info
+	if stored.Code != "" {
+		salt := make([]byte, 16)
+		if _, err = rand.Read(salt); err != nil {
+			return err
+		}
+		stored.Salt = hex.EncodeToString(salt)
+		stored.CodeHash = hex.EncodeToString(r.hashCode(stored.Salt, stored.Code))
+		stored.SealedCode, err = r.seal([]byte(stored.Code))
+		if err != nil {
+			return err
+		}
+		stored.Code = ""
+	}
+
+	ttl := defaultVerificationTTL
+	if !stored.ExpiresAt.IsZero() {
+		ttl = time.Until(stored.ExpiresAt)
+		if ttl <= 0 {
+			return r.DeleteVerificationInfo(ctx, phoneOrEmail, purpose)
+		}
+	}
+
+	data, err := json.Marshal(&stored)
 	str := string(data)
 	if err != nil {
 		return err
 	}
-	return r.redisClient.Set(ctx, strings.ToLower(phoneOrEmail), str, time.Minute*5).Err()
+	return r.redisClient.Set(ctx, verificationKey(phoneOrEmail, purpose), str, ttl).Err()
+}
+
+func (r *Repository) DeleteVerificationInfo(ctx context.Context, phoneOrEmail, purpose string) error {
+	return r.redisClient.Del(ctx, verificationKey(phoneOrEmail, purpose)).Err()
+}
+
+// MatchVerificationCode reports whether code matches the one stored in info.
+// The comparison runs in constant time.
+func (r *Repository) MatchVerificationCode(info *VerificationInfo, code string) bool {
+	if info.CodeHash != "" {
+		expected, err := hex.DecodeString(info.CodeHash)
+		if err != nil {
+			return false
+		}
+		return hmac.Equal(expected, r.hashCode(info.Salt, code))
+	}
+
+	if r.cfg.AllowPlaintextCodes && info.Code != "" {
+		return subtle.ConstantTimeCompare([]byte(info.Code), []byte(code)) == 1
+	}
+
+	return false
+}
+
+// RecoverVerificationCode returns the plaintext code of info so it can be
+// delivered again, or "" if the record predates resending.
+func (r *Repository) RecoverVerificationCode(info *VerificationInfo) (string, error) {
+	if info.SealedCode != "" {
+		code, err := r.open(info.SealedCode)
+		if err != nil {
+			return "", err
+		}
+		return string(code), nil
+	}
+
+	if r.cfg.AllowPlaintextCodes {
+		return info.Code, nil
+	}
+
+	return "", nil
+}
+
+// markDeliveryScript updates the delivery fields of a record in place so a
+// resend cannot overwrite attempts counted meanwhile. ARGV is the salt the
+// caller read, the delivery time and whether delivery failed.
+var markDeliveryScript = redis.NewScript(`
+local raw = redis.call('GET', KEYS[1])
+if not raw then
+	return 0
+end
+local info = cjson.decode(raw)
+if (info['Salt'] or '') ~= ARGV[1] then
+	return 0
+end
+info['LastAttempt'] = ARGV[2]
+info['DeliveryFailed'] = ARGV[3] == '1'
+redis.call('SET', KEYS[1], cjson.encode(info), 'KEEPTTL')
+return 1
+`)
+
+// MarkVerificationDelivery records a new delivery of the code in info, which
+// must have been read from the same key. It reports false if the record has
+// expired or been replaced since.
+func (r *Repository) MarkVerificationDelivery(ctx context.Context, phoneOrEmail, purpose string, info *VerificationInfo, at time.Time, failed bool) (bool, error) {
+	failedArg := "0"
+	if failed {
+		failedArg = "1"
+	}
+
+	n, err := markDeliveryScript.Run(ctx, r.redisClient, []string{verificationKey(phoneOrEmail, purpose)}, info.Salt, at.Format(time.RFC3339Nano), failedArg).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
 }
 
-func (r *Repository) DeleteVerificationInfo(ctx context.Context, phoneOrEmail string) error {
-	return r.redisClient.Del(ctx, phoneOrEmail).Err()
+func (r *Repository) hashCode(salt, code string) []byte {
+	mac := hmac.New(sha256.New, []byte(r.cfg.CodeSecret))
+	mac.Write([]byte(salt))
+	mac.Write([]byte{0})
+	mac.Write([]byte(code))
+	return mac.Sum(nil)
 }
@@ -3,44 +3,218 @@ package messaging
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"math/rand"
 	"net"
-	"strconv"
+	"regexp"
 	"strings"
 	"text/template"
 	"time"
 
 	"encoding/base64"
 
+	"github.com/more-than-code/messaging/bypass"
+	"github.com/more-than-code/messaging/chat-vendor"
 	"github.com/more-than-code/messaging/constant"
 	"github.com/more-than-code/messaging/email-vendor"
+	"github.com/more-than-code/messaging/emailaddr"
 	"github.com/more-than-code/messaging/pb"
+	"github.com/more-than-code/messaging/phone"
 	"github.com/more-than-code/messaging/repository"
 	"github.com/more-than-code/messaging/sms-vendor"
+	"github.com/more-than-code/messaging/voice-vendor"
 
 	"github.com/more-than-code/messaging/util"
 
 	"github.com/kelseyhightower/envconfig"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ServerConfig struct {
-	SmsProvider     string `envconfig:"SMS_PROVIDER"`
-	EmailProvider   string `envconfig:"EMAIL_PROVIDER"`
-	EmailDomains    string `envconfig:"EMAIL_DOMAINS"`
-	EmailBypassCode string `envconfig:"EMAIL_BYPASS_CODE"`
-	PhoneBypassCode string `envconfig:"PHONE_BYPASS_CODE"`
-	IsDev           bool   `envconfig:"IS_DEV"`
-	ServerPort      string `envconfig:"SERVER_PORT"`
-	ProductName     string `envconfig:"PRODUCT_NAME"`
+	SmsProvider   string `envconfig:"SMS_PROVIDER"`
+	EmailProvider string `envconfig:"EMAIL_PROVIDER"`
+	ChatProvider  string `envconfig:"CHAT_PROVIDER"`
+	VoiceProvider string `envconfig:"VOICE_PROVIDER"`
+	IsDev         bool   `envconfig:"IS_DEV"`
+	ServerPort    string `envconfig:"SERVER_PORT"`
+	ProductName   string `envconfig:"PRODUCT_NAME"`
+	// BypassRegistryFile lists test identities with fixed codes; see
+	// bypass-registry.example.json.
+	BypassRegistryFile string `envconfig:"BYPASS_REGISTRY_FILE"`
+	// SmsRoutesFile picks SMS vendors by destination country; see
+	// sms-routes.example.json. Unrouted countries use SMS_PROVIDER.
+	SmsRoutesFile string `envconfig:"SMS_ROUTES_FILE"`
+	// AdminToken authenticates MessagingAdmin calls; the service is not
+	// served when it is empty.
+	AdminToken string `envconfig:"ADMIN_TOKEN"`
+	// Defaults for requests that leave code_length, code_alphabet or
+	// ttl_seconds unset.
+	CodeLength   int           `envconfig:"CODE_LENGTH" default:"4"`
+	CodeAlphabet string        `envconfig:"CODE_ALPHABET" default:"NUMERIC"`
+	CodeTtl      time.Duration `envconfig:"CODE_TTL" default:"5m"`
+	// CodeGenerator is RANDOM, or FIXED (dev only) to always issue FixedCode.
+	CodeGenerator string `envconfig:"CODE_GENERATOR" default:"RANDOM"`
+	FixedCode     string `envconfig:"FIXED_CODE"`
+	// TotpSkew is how many 30 second steps either side of now a TOTP code
+	// may come from.
+	TotpSkew int `envconfig:"TOTP_SKEW" default:"1"`
+	// MaxAttempts is how many wrong guesses a code survives; the next wrong
+	// guess returns MAXIMUM_ATTEMPTS and discards the code.
+	MaxAttempts int `envconfig:"MAX_ATTEMPTS" default:"3"`
+	// LockoutDurations escalate with each MAXIMUM_ATTEMPTS of an identity
+	// until it goes LockoutResetAfter past a lockout without another one.
+	// Empty disables lockouts.
+	LockoutDurations  []time.Duration `envconfig:"LOCKOUT_DURATIONS" default:"15m,1h,24h"`
+	LockoutResetAfter time.Duration   `envconfig:"LOCKOUT_RESET_AFTER" default:"24h"`
+	// FailedAttemptWindow is how long wrong guesses that no pending code
+	// counts, e.g. TOTP codes or codes guessed with none pending, add up
+	// towards a lockout after MaxAttempts.
+	FailedAttemptWindow time.Duration `envconfig:"FAILED_ATTEMPT_WINDOW" default:"15m"`
+	// Send quotas as comma-separated <count>/<window> tiers, all of which
+	// must allow a send. They are kept per phone or email, per client IP and
+	// per SMS country calling code; an empty value disables that scope.
+	IdentityRateLimits string `envconfig:"RATE_LIMITS_IDENTITY" default:"1/1m,5/1h,10/24h"`
+	IpRateLimits       string `envconfig:"RATE_LIMITS_IP" default:"10/1h,50/24h"`
+	CountryRateLimits  string `envconfig:"RATE_LIMITS_COUNTRY"`
+	// SmsRateLimits are SendSms quotas per phone number. They, and the
+	// country quotas of SendSms, are kept apart from those of codes so
+	// notifications and codes don't hold each other up.
+	SmsRateLimits string `envconfig:"RATE_LIMITS_SMS" default:"10/1h,50/24h"`
+	// DefaultPhoneRegion is the ISO 3166-1 region assumed for phone numbers
+	// given without a country calling code.
+	DefaultPhoneRegion string `envconfig:"DEFAULT_PHONE_REGION" default:"CN"`
+	// DisposableEmailDomains, plus those listed one per line in
+	// DisposableEmailDomainsFile, can't be sent codes. Subdomains are
+	// blocked too.
+	DisposableEmailDomains     []string `envconfig:"DISPOSABLE_EMAIL_DOMAINS"`
+	DisposableEmailDomainsFile string   `envconfig:"DISPOSABLE_EMAIL_DOMAINS_FILE"`
+	// CanonicalEmailDomains deliver "a.b+tag@" to "ab@", so those variants
+	// share one identity send quota. Empty keys quotas by exact address.
+	CanonicalEmailDomains []string `envconfig:"CANONICAL_EMAIL_DOMAINS"`
 }
 
+// newSmsVendor chains the vendors of the comma-separated SMS_PROVIDER in
+// failover order, routing by destination country when SMS_ROUTES_FILE is set.
+func newSmsVendor(cfg *ServerConfig) (sms.SmsVendor, error) {
+	var failoverCfg sms.FailoverConfig
+	err := envconfig.Process("", &failoverCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Routes share one instance of each vendor with the SMS_PROVIDER chain.
+	pool := sms.NewVendorPool(failoverCfg)
+
+	var fallback sms.SmsVendor
+	if cfg.SmsProvider != "" {
+		var specs []sms.VendorSpec
+		for _, provider := range strings.Split(cfg.SmsProvider, ",") {
+			specs = append(specs, sms.VendorSpec{Provider: strings.TrimSpace(provider)})
+		}
+
+		fallback, err = pool.Chain(specs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.SmsRoutesFile == "" {
+		if fallback == nil {
+			return nil, errors.New("SMS_PROVIDER or SMS_ROUTES_FILE is required")
+		}
+		return fallback, nil
+	}
+
+	router, err := sms.LoadRouter(cfg.SmsRoutesFile, pool, fallback)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("loaded sms routes for %d country codes", router.Len())
+
+	return router, nil
+}
+
+// rateLimits holds the parsed send quotas of ServerConfig.
+type rateLimits struct {
+	identity []repository.RateLimit
+	ip       []repository.RateLimit
+	country  []repository.RateLimit
+	sms      []repository.RateLimit
+}
+
+func parseRateLimits(cfg *ServerConfig) (*rateLimits, error) {
+	var limits rateLimits
+	var err error
+
+	if limits.identity, err = repository.ParseRateLimits(cfg.IdentityRateLimits); err != nil {
+		return nil, fmt.Errorf("RATE_LIMITS_IDENTITY: %w", err)
+	}
+	if limits.ip, err = repository.ParseRateLimits(cfg.IpRateLimits); err != nil {
+		return nil, fmt.Errorf("RATE_LIMITS_IP: %w", err)
+	}
+	if limits.country, err = repository.ParseRateLimits(cfg.CountryRateLimits); err != nil {
+		return nil, fmt.Errorf("RATE_LIMITS_COUNTRY: %w", err)
+	}
+	if limits.sms, err = repository.ParseRateLimits(cfg.SmsRateLimits); err != nil {
+		return nil, fmt.Errorf("RATE_LIMITS_SMS: %w", err)
+	}
+
+	return &limits, nil
+}
+
+// checkLockoutDurations rejects durations Redis can't expire a lockout
+// after.
+func checkLockoutDurations(durations []time.Duration) error {
+	for _, d := range durations {
+		if d <= 0 {
+			return fmt.Errorf("LOCKOUT_DURATIONS: %s is not a positive duration", d)
+		}
+	}
+	return nil
+}
+
+// checkCodeDefaults resolves the settings of a request that sets none, so a
+// bad CODE_LENGTH, CODE_ALPHABET or CODE_TTL fails startup rather than every
+// such request.
+func checkCodeDefaults(cfg *ServerConfig) error {
+	s := &Server{cfg: cfg}
+	_, _, _, err := s.resolveCodeSettings(&pb.GenerateVerificationCodeRequest{})
+	if err != nil {
+		return fmt.Errorf("invalid code defaults: %w", err)
+	}
+	return nil
+}
+
+const (
+	minCodeLength = 4
+	maxCodeLength = 12
+	minCodeTtl    = time.Second * 30
+	maxCodeTtl    = time.Hour
+)
+
+// purposePattern restricts purposes to short lowercase identifiers so they
+// cannot collide with the key separator.
+var purposePattern = regexp.MustCompile(`^[a-z0-9_.-]{0,64}$`)
+
+const (
+	numericAlphabet = "0123456789"
+	// alphanumericAlphabet leaves out 0, 1, I and O, which are easily misread.
+	alphanumericAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+)
+
 type Server struct {
-	smsVendor sms.SmsVendor
-	repo      *repository.Repository
-	cfg       *ServerConfig
+	smsVendor   sms.SmsVendor
+	chatVendor  chat.ChatVendor
+	voiceVendor voice.VoiceVendor
+	codeGen     CodeGenerator
+	bypass      *bypass.Registry
+	blocklist   *emailaddr.Blocklist
+	limits      *rateLimits
+	repo        *repository.Repository
+	cfg         *ServerConfig
 	pb.UnimplementedMessagingServer
 }
 
@@ -52,36 +226,99 @@ func NewServer() error {
 		log.Fatal(err)
 	}
 
+	err = checkLockoutDurations(cfg.LockoutDurations)
+	if err != nil {
+		return err
+	}
+
+	err = checkCodeDefaults(&cfg)
+	if err != nil {
+		return err
+	}
+
 	lis, err := net.Listen("tcp", cfg.ServerPort)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 	var opts []grpc.ServerOption
 	opts = append(opts, grpc.MaxRecvMsgSize(1024*1024*10))
+	if cfg.AdminToken != "" {
+		opts = append(opts, grpc.UnaryInterceptor(adminAuthInterceptor(cfg.AdminToken)))
+	}
 
 	grpcServer := grpc.NewServer(opts...)
-	var smsVendor sms.SmsVendor
+	smsVendor, err := newSmsVendor(&cfg)
+	if err != nil {
+		return err
+	}
+
+	var chatVendor chat.ChatVendor
+
+	switch cfg.ChatProvider {
+	case "":
+	case "WHATSAPP":
+		chatVendor, err = chat.NewWhatsAppVendor()
+	default:
+		log.Fatal("Invalid chat provider")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var voiceVendor voice.VoiceVendor
 
-	switch cfg.SmsProvider {
-	case "VOLC":
-		smsVendor, err = sms.NewVolcVendor()
-	case "BYTEPLUS":
-		smsVendor, err = sms.NewBytePlusVendor()
+	switch cfg.VoiceProvider {
+	case "":
+	case "HTTP":
+		voiceVendor, err = voice.NewHttpVendor()
+	case "FAKE":
+		if !cfg.IsDev {
+			return errors.New("FAKE voice provider is only allowed when IS_DEV is set")
+		}
+		voiceVendor = &voice.FakeVendor{}
 	default:
-		log.Fatal("Invalid SMS provider")
+		log.Fatal("Invalid voice provider")
+	}
+
+	if err != nil {
+		return err
 	}
 
+	codeGen, err := newCodeGenerator(&cfg)
 	if err != nil {
 		return err
 	}
 
+	limits, err := parseRateLimits(&cfg)
+	if err != nil {
+		return err
+	}
+
+	bypassRegistry, err := bypass.Load(cfg.BypassRegistryFile)
+	if err != nil {
+		return err
+	}
+	log.Printf("loaded %d bypass entries", bypassRegistry.Len())
+
+	blocklist, err := emailaddr.LoadBlocklist(cfg.DisposableEmailDomainsFile, cfg.DisposableEmailDomains)
+	if err != nil {
+		return err
+	}
+	log.Printf("loaded %d disposable email domains", blocklist.Len())
+
 	repo, err := repository.NewRepository()
 	if err != nil {
 		return err
 	}
 
 	log.Printf("messaging server starting gRPC listener on %s", cfg.ServerPort)
-	pb.RegisterMessagingServer(grpcServer, &Server{smsVendor: smsVendor, repo: repo, cfg: &cfg})
+	if cfg.AdminToken != "" {
+		pb.RegisterMessagingAdminServer(grpcServer, &AdminServer{repo: repo, cfg: &cfg})
+	} else {
+		log.Printf("ADMIN_TOKEN not set, MessagingAdmin service disabled")
+	}
+	pb.RegisterMessagingServer(grpcServer, &Server{smsVendor: smsVendor, chatVendor: chatVendor, voiceVendor: voiceVendor, codeGen: codeGen, bypass: bypassRegistry, blocklist: blocklist, limits: limits, repo: repo, cfg: &cfg})
 	err = grpcServer.Serve(lis)
 
 	if err != nil {
@@ -96,63 +333,391 @@ func NewServer() error {
 
 func (s *Server) GenerateVerificationCode(ctx context.Context, req *pb.GenerateVerificationCodeRequest) (*pb.GenerateVerificationCodeResponse, error) {
 	res := &pb.GenerateVerificationCodeResponse{Status: pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_DONE, Msg: string(constant.MsgDone)}
-	var err error
 
-	found, err := s.repo.GetVerificationInfo(ctx, req.PhoneOrEmail)
+	phoneOrEmail, err := normalizeIdentity(req.PhoneOrEmail, s.cfg.DefaultPhoneRegion)
+	if err != nil {
+		log.Printf("Rejected identity %s: %v", req.PhoneOrEmail, err)
+
+		res.Status = pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_INVALID_ARGUMENTS
+		res.Msg = string(constant.MsgInvalidArguments)
+
+		return res, nil
+	}
+	req.PhoneOrEmail = phoneOrEmail
+
+	if util.IsEmail(req.PhoneOrEmail) && s.blocklist.Blocked(util.DomainFromAddress(req.PhoneOrEmail)) {
+		log.Printf("Rejected disposable email address %s", req.PhoneOrEmail)
+
+		res.Status = pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_INVALID_ARGUMENTS
+		res.Msg = string(constant.MsgInvalidArguments)
+
+		return res, nil
+	}
+
+	isLink := req.Mode == pb.VerificationMode_VERIFICATION_MODE_LINK
 
+	// Bad input must be caught before any quota is spent or code stored, or
+	// it would pass for a failed delivery.
+	var mailVendor email.EmailVendor
+	length, alphabet, ttl, err := s.resolveCodeSettings(req)
+	if err == nil {
+		req.Channel, err = s.resolveChannel(req)
+	}
+	if err == nil {
+		mailVendor, err = s.prepareDelivery(req, isLink)
+	}
+	if err != nil {
+		log.Printf("Rejected request for %s: %v", req.PhoneOrEmail, err)
+
+		res.Status = pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_INVALID_ARGUMENTS
+		res.Msg = string(constant.MsgInvalidArguments)
+
+		return res, nil
+	}
+
+	locked, err := s.repo.LockoutRemaining(ctx, req.PhoneOrEmail)
 	if err != nil {
 		return nil, err
 	}
 
-	if found != nil {
-		if time.Since(found.LastAttempt).Minutes() <= 1 {
-			str := fmt.Sprintf("Code sent for %s within 1 minute", req.PhoneOrEmail)
-			fmt.Println(str)
+	if locked > 0 {
+		log.Printf("%s locked out for %s", req.PhoneOrEmail, locked)
 
-			res.Status = pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_SENDING_TOO_FREQUENTLY
-			res.Msg = string(constant.MsgSendingTooFrequently)
+		res.Status = pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_LOCKED
+		res.Msg = string(constant.MsgLocked)
+		res.RetryAfterSeconds = durationToSeconds(locked)
 
-			return res, nil
-		}
+		return res, nil
+	}
+
+	found, err := s.repo.GetVerificationInfo(ctx, req.PhoneOrEmail, req.Purpose)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The identity's quota was spent on a code that never arrived, so
+	// resending it, e.g. through another channel, doesn't spend it again.
+	scopes := s.rateLimitScopes(req)
+	charged := scopes
+	if found != nil && found.DeliveryFailed {
+		charged = s.sharedRateLimitScopes(req)
 	}
 
-	code := strconv.Itoa(rand.Intn(9000) + 1000)
+	// Don't silently replace a code the user never received; the caller has
+	// to ask for it to be resent.
+	if found != nil && found.DeliveryFailed && !req.Resend {
+		log.Printf("Previous delivery to %s failed, needing resending", req.PhoneOrEmail)
+
+		wait, err := s.repo.RateLimitWait(ctx, charged)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Status = pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_NEEDING_RESENDING
+		res.Msg = string(constant.MsgNeedingResending)
+		res.RetryAfterSeconds = durationToSeconds(wait)
+		s.describePendingCode(res, found)
+
+		return res, nil
+	}
 
-	message, err := templateToMessage(req.MessageTemplate, code)
+	allowed, retryAfter, err := s.repo.ConsumeRateLimits(ctx, charged)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if util.IsEmail(req.PhoneOrEmail) {
-		mailVendor, mailErr := s.resolveEmailVendor(req.EmailConfig)
-		if mailErr != nil {
-			return nil, mailErr
+	if !allowed {
+		log.Printf("Send quota exhausted for %s, retry after %s", req.PhoneOrEmail, retryAfter)
+
+		res.Status = pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_SENDING_TOO_FREQUENTLY
+		res.Msg = string(constant.MsgSendingTooFrequently)
+		res.RetryAfterSeconds = durationToSeconds(retryAfter)
+
+		return res, nil
+	}
+
+	var code string
+	resending := req.Resend && found != nil && found.Link == isLink
+	if resending {
+		code, err = s.repo.RecoverVerificationCode(found)
+		if err != nil {
+			return nil, err
+		}
+		resending = code != ""
+	}
+
+	if resending {
+		vendor, sendErr := s.deliverCode(req, mailVendor, code, isLink)
+
+		// The code, its attempts and its expiry stay as they are.
+		ok, err := s.repo.MarkVerificationDelivery(ctx, req.PhoneOrEmail, req.Purpose, found, time.Now(), sendErr != nil)
+		if err != nil {
+			return nil, err
+		}
+
+		s.recordSend(ctx, req, vendor, true, sendErr)
+
+		if sendErr != nil {
+			return nil, deliveryStatus(sendErr)
+		}
+
+		if ok {
+			log.Println("Resent code to " + req.PhoneOrEmail)
+			return s.describeSentCode(ctx, res, found, scopes)
 		}
-		err = mailVendor.SendCode(req.PhoneOrEmail, req.Subject, message)
+
+		// The code expired or was consumed while it was being resent; issue a
+		// new one instead.
+		log.Printf("Code for %s changed while resending, issuing a new one", req.PhoneOrEmail)
+	}
+
+	if isLink {
+		code, err = newVerificationToken()
 	} else {
-		err = s.smsVendor.SendCodeNProduct(req.PhoneOrEmail, code, s.cfg.ProductName)
+		code, err = s.codeGen.Generate(length, alphabet)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	vendor, sendErr := s.deliverCode(req, mailVendor, code, isLink)
+
+	now := time.Now()
+	ph := repository.VerificationInfo{Code: code, Attempt: 0, LastAttempt: now, ExpiresAt: now.Add(ttl), Link: isLink, DeliveryFailed: sendErr != nil}
+
+	err = s.repo.SetVerificationInfo(ctx, req.PhoneOrEmail, req.Purpose, &ph)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if isLink {
+		ref := repository.TokenRef{PhoneOrEmail: req.PhoneOrEmail, Purpose: req.Purpose}
+		err = s.repo.SetVerificationToken(ctx, code, &ref, ph.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.recordSend(ctx, req, vendor, false, sendErr)
+
+	if sendErr != nil {
+		return nil, deliveryStatus(sendErr)
 	}
 
+	log.Println("Sent code to " + req.PhoneOrEmail)
+
+	return s.describeSentCode(ctx, res, &ph, scopes)
+}
+
+// prepareDelivery checks that the request's templates render and, for email,
+// resolves the vendor from its email config.
+func (s *Server) prepareDelivery(req *pb.GenerateVerificationCodeRequest, isLink bool) (email.EmailVendor, error) {
+	// Templates can fail on execution as well as parsing, so render them
+	// around a stand-in code.
+	_, err := renderMessage(req, "", isLink)
 	if err != nil {
 		return nil, err
 	}
 
-	ph := repository.VerificationInfo{Code: code, Attempt: 0, LastAttempt: time.Now()}
+	if req.Channel != pb.Channel_CHANNEL_EMAIL {
+		return nil, nil
+	}
+
+	return s.resolveEmailVendor(req.EmailConfig)
+}
+
+// errInvalidDelivery marks a template or email config the caller got wrong.
+var errInvalidDelivery = errors.New("invalid delivery settings")
+
+// renderMessage fills the request's templates in around code.
+func renderMessage(req *pb.GenerateVerificationCodeRequest, code string, isLink bool) (string, error) {
+	data := messageData{Params: req.TemplateParams}
+	var err error
+	if isLink {
+		data.Token = code
+		data.Link, err = templateToMessage(req.LinkTemplate, data)
+		if err != nil {
+			return "", fmt.Errorf("%w: link template: %v", errInvalidDelivery, err)
+		}
+	} else {
+		data.Code = code
+	}
+
+	message, err := templateToMessage(req.MessageTemplate, data)
+	if err != nil {
+		return "", fmt.Errorf("%w: message template: %v", errInvalidDelivery, err)
+	}
+
+	return message, nil
+}
+
+// deliverCode renders the request's templates around code and hands the
+// message to the vendor for the identity. mailVendor comes from
+// prepareDelivery.
+func (s *Server) deliverCode(req *pb.GenerateVerificationCodeRequest, mailVendor email.EmailVendor, code string, isLink bool) (string, error) {
+	message, err := renderMessage(req, code, isLink)
+	if err != nil {
+		return "", err
+	}
+
+	switch req.Channel {
+	case pb.Channel_CHANNEL_EMAIL:
+		return strings.ToUpper(req.EmailConfig.Provider), mailVendor.SendCode(req.PhoneOrEmail, req.Subject, message)
+	case pb.Channel_CHANNEL_WHATSAPP:
+		return s.cfg.ChatProvider, s.chatVendor.SendCode(req.PhoneOrEmail, code)
+	case pb.Channel_CHANNEL_VOICE:
+		return s.cfg.VoiceProvider, s.voiceVendor.CallCode(req.PhoneOrEmail, code)
+	case pb.Channel_CHANNEL_SMS:
+		receipt, err := s.smsVendor.SendCode(req.PhoneOrEmail, smsTemplateParams(req.TemplateParams, code, s.cfg.ProductName))
+		return smsReceiptVendor(receipt, err), err
+	default:
+		return "", fmt.Errorf("unsupported channel: %s", req.Channel)
+	}
+}
+
+// smsReceiptVendor returns the vendor that accepted or, if it says, rejected
+// an SMS.
+func smsReceiptVendor(receipt *sms.Receipt, err error) string {
+	var sendErr *sms.SendError
+	switch {
+	case err == nil:
+		return receipt.Vendor
+	case errors.As(err, &sendErr):
+		return sendErr.Vendor
+	default:
+		return ""
+	}
+}
+
+// deliveryStatus maps a delivery error to a gRPC status so callers can tell a
+// rejected message, e.g. an invalid number, from a vendor outage.
+func deliveryStatus(err error) error {
+	var sendErr *sms.SendError
+	switch {
+	case errors.As(err, &sendErr) && !sendErr.Retryable:
+		return status.Errorf(codes.InvalidArgument, "message rejected: %v", err)
+	case sendErr != nil:
+		return status.Errorf(codes.Unavailable, "vendor unavailable: %v", err)
+	case errors.Is(err, sms.ErrUnsupportedMessage):
+		return status.Errorf(codes.InvalidArgument, "message not supported: %v", err)
+	case errors.Is(err, errInvalidDelivery):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "delivery failed: %v", err)
+	}
+}
+
+// smsTemplateParams merges the caller's template params with the code and the
+// configured product name, which callers can't override, even when
+// PRODUCT_NAME is empty.
+func smsTemplateParams(custom map[string]string, code, product string) sms.TemplateParams {
+	params := make(sms.TemplateParams, len(custom)+2)
+	for name, value := range custom {
+		params[name] = value
+	}
+	params["product"] = product
+	params["code"] = code
+	return params
+}
+
+// resolveChannel returns the channel the request asks for, or the one implied
+// by its identity, after checking it can reach the identity and has a vendor.
+func (s *Server) resolveChannel(req *pb.GenerateVerificationCodeRequest) (pb.Channel, error) {
+	isEmail := util.IsEmail(req.PhoneOrEmail)
+
+	channel := req.Channel
+	if channel == pb.Channel_CHANNEL_UNSPECIFIED {
+		channel = pb.Channel_CHANNEL_SMS
+		if isEmail {
+			channel = pb.Channel_CHANNEL_EMAIL
+		}
+	}
+
+	if isEmail != (channel == pb.Channel_CHANNEL_EMAIL) {
+		return 0, fmt.Errorf("channel %s can't deliver to %s", channel, req.PhoneOrEmail)
+	}
+
+	switch channel {
+	case pb.Channel_CHANNEL_SMS, pb.Channel_CHANNEL_EMAIL:
+	case pb.Channel_CHANNEL_WHATSAPP:
+		if s.chatVendor == nil {
+			return 0, fmt.Errorf("channel %s is not configured", channel)
+		}
+	case pb.Channel_CHANNEL_VOICE:
+		if s.voiceVendor == nil {
+			return 0, fmt.Errorf("channel %s is not configured", channel)
+		}
+	default:
+		return 0, fmt.Errorf("unsupported channel: %s", channel)
+	}
+
+	return channel, nil
+}
+
+// channelName is how a channel appears in send records, e.g. "whatsapp".
+func channelName(channel pb.Channel) string {
+	return strings.ToLower(strings.TrimPrefix(channel.String(), "CHANNEL_"))
+}
+
+// recordSend adds a delivery to the recent sends list. Failing to record it
+// doesn't fail the request.
+func (s *Server) recordSend(ctx context.Context, req *pb.GenerateVerificationCodeRequest, vendor string, resend bool, sendErr error) {
+	send := repository.SendRecord{
+		PhoneOrEmail: req.PhoneOrEmail,
+		Purpose:      req.Purpose,
+		Channel:      channelName(req.Channel),
+		Vendor:       vendor,
+		SentAt:       time.Now(),
+		Resend:       resend,
+		Failed:       sendErr != nil,
+	}
 
-	err = s.repo.SetVerificationInfo(ctx, req.PhoneOrEmail, &ph)
+	if err := s.repo.RecordSend(ctx, &send); err != nil {
+		log.Printf("Failed to record send to %s: %v", req.PhoneOrEmail, err)
+	}
+}
 
+// describeSentCode fills in the metadata of a successful send of info.
+func (s *Server) describeSentCode(ctx context.Context, res *pb.GenerateVerificationCodeResponse, info *repository.VerificationInfo, scopes []repository.RateLimitScope) (*pb.GenerateVerificationCodeResponse, error) {
+	wait, err := s.repo.RateLimitWait(ctx, scopes)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Println("Sent to " + req.PhoneOrEmail + " with code " + code)
+	res.RetryAfterSeconds = durationToSeconds(wait)
+	s.describePendingCode(res, info)
 
 	return res, nil
 }
 
+func (s *Server) describePendingCode(res *pb.GenerateVerificationCodeResponse, info *repository.VerificationInfo) {
+	res.AttemptsRemaining = int32(max(s.cfg.MaxAttempts-info.Attempt, 0))
+	if !info.ExpiresAt.IsZero() {
+		res.TtlSeconds = durationToSeconds(time.Until(info.ExpiresAt))
+		res.ExpiresAt = info.ExpiresAt.Unix()
+	}
+}
+
 func (s *Server) ValidateVerificationCode(ctx context.Context, req *pb.ValidateVerificationCodeRequest) (*pb.ValidateVerificationCodeResponse, error) {
-	log.Printf("[ValidateVerificationCode] START - PhoneOrEmail: %s, Code: %s", req.PhoneOrEmail, req.VerificationCode)
+	log.Printf("[ValidateVerificationCode] START - PhoneOrEmail: %s, Purpose: %s", req.PhoneOrEmail, req.Purpose)
+
+	if !purposePattern.MatchString(req.Purpose) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid purpose: %q", req.Purpose)
+	}
+
+	phoneOrEmail, err := normalizeIdentity(req.PhoneOrEmail, s.cfg.DefaultPhoneRegion)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid phone_or_email: %v", err)
+	}
+	req.PhoneOrEmail = phoneOrEmail
+
+	if req.ClientIp != "" && net.ParseIP(req.ClientIp) == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid client_ip: %q", req.ClientIp)
+	}
 
 	var msg = constant.MsgValid
 	var status = pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_VALID
@@ -162,56 +727,389 @@ func (s *Server) ValidateVerificationCode(ctx context.Context, req *pb.ValidateV
 	// 	return &pb.ValidateVerificationCodeResponse{Status: status, Msg: string(msg)}, nil
 	// }
 
-	// Check email bypass code
-	emailDomains := strings.Split(s.cfg.EmailDomains, ",")
-	userDomain := util.DomainFromAddress(req.PhoneOrEmail)
-	if util.Contains(emailDomains, userDomain) && req.VerificationCode == s.cfg.EmailBypassCode {
-		log.Printf("[ValidateVerificationCode] Email bypass code matched for domain: %s", userDomain)
-		return &pb.ValidateVerificationCodeResponse{Status: status, Msg: string(msg)}, nil
+	locked, err := s.repo.LockoutRemaining(ctx, req.PhoneOrEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if locked == 0 && req.ClientIp != "" {
+		locked, err = s.repo.LockoutRemaining(ctx, ipQuotaKey(req.ClientIp))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if locked > 0 {
+		log.Printf("[ValidateVerificationCode] %s (client %s) locked out for %s", req.PhoneOrEmail, req.ClientIp, locked)
+		return &pb.ValidateVerificationCodeResponse{
+			Status:            pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_LOCKED,
+			Msg:               string(constant.MsgLockedOut),
+			RetryAfterSeconds: durationToSeconds(locked),
+		}, nil
+	}
+
+	entry := s.bypass.Match(req.PhoneOrEmail, req.VerificationCode, time.Now())
+	if entry != nil {
+		entryLocked, err := s.repo.LockoutRemaining(ctx, bypassEntryIdentity(entry.Name))
+		if err != nil {
+			return nil, err
+		}
+		if entryLocked > 0 {
+			log.Printf("[ValidateVerificationCode] Bypass entry %s locked out for %s, ignoring it", entry.Name, entryLocked)
+			entry = nil
+		}
 	}
 
-	// Check phone bypass code
-	if !util.IsEmail(req.PhoneOrEmail) && req.VerificationCode == s.cfg.PhoneBypassCode {
-		log.Printf("[ValidateVerificationCode] Phone bypass code matched")
+	if entry != nil {
+		log.Printf("[ValidateVerificationCode] Bypass entry %s matched for %s", entry.Name, req.PhoneOrEmail)
+
+		use := repository.BypassUse{Entry: entry.Name, Identity: req.PhoneOrEmail, Purpose: req.Purpose, At: time.Now()}
+		err := s.repo.RecordBypassUse(ctx, &use)
+		if err != nil {
+			// Refuse bypasses that cannot be audited.
+			log.Printf("[ValidateVerificationCode] ERROR recording bypass use: %v", err)
+			return nil, err
+		}
+
 		return &pb.ValidateVerificationCodeResponse{Status: status, Msg: string(msg)}, nil
 	}
 
-	found, err := s.repo.GetVerificationInfo(ctx, req.PhoneOrEmail)
+	found, err := s.repo.GetVerificationInfo(ctx, req.PhoneOrEmail, req.Purpose)
 	if err != nil {
 		log.Printf("[ValidateVerificationCode] ERROR getting verification info: %v", err)
 		return nil, err
 	}
 
+	var attempt int
+
 	log.Printf("[ValidateVerificationCode] Found in Redis: %v", found != nil)
 	if found != nil {
-		log.Printf("[ValidateVerificationCode] Stored Code: %s, Request Code: %s, Attempt: %d", found.Code, req.VerificationCode, found.Attempt)
+		// Alphanumeric codes are generated in upper case; let users type either.
+		matched := s.repo.MatchVerificationCode(found, strings.ToUpper(req.VerificationCode))
+
+		var result repository.AttemptResult
+		result, attempt, err = s.repo.RecordVerificationAttempt(ctx, req.PhoneOrEmail, req.Purpose, found, matched, s.cfg.MaxAttempts)
+		if err != nil {
+			log.Printf("[ValidateVerificationCode] ERROR recording attempt: %v", err)
+			return nil, err
+		}
+
+		log.Printf("[ValidateVerificationCode] Matched: %v, Attempt: %d", matched, attempt)
 
-		if found.Code == req.VerificationCode {
+		switch result {
+		case repository.AttemptValid:
 			log.Printf("[ValidateVerificationCode] Code MATCHED - returning VALID")
-			s.repo.DeleteVerificationInfo(ctx, strings.ToLower(req.PhoneOrEmail))
-		} else {
-			log.Printf("[ValidateVerificationCode] Code MISMATCH")
-			if found.Attempt >= 3 {
-				log.Printf("[ValidateVerificationCode] Maximum attempts reached")
-				msg = constant.MsgMaximumAttempts
-				status = pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_MAXIMUM_ATTEMPTS
-				s.repo.DeleteVerificationInfo(ctx, strings.ToLower(req.PhoneOrEmail))
-			} else {
-				log.Printf("[ValidateVerificationCode] Invalid code, incrementing attempt to %d", found.Attempt+1)
-				msg = constant.MsgInvalid
-				status = pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_INVALID
-				found.Attempt++
-				s.repo.SetVerificationInfo(ctx, req.PhoneOrEmail, found)
+		case repository.AttemptInvalid:
+			msg = constant.MsgInvalid
+			status = pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_INVALID
+		case repository.AttemptMaximumReached:
+			msg = constant.MsgMaximumAttempts
+			status = pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_MAXIMUM_ATTEMPTS
+
+			// Without a lockout, a fresh code would allow the next round of
+			// guesses right away.
+			locked, err = s.repo.LockIdentity(ctx, req.PhoneOrEmail, s.cfg.LockoutDurations, s.cfg.LockoutResetAfter)
+			if err != nil {
+				log.Printf("[ValidateVerificationCode] ERROR locking identity: %v", err)
+				return nil, err
 			}
+			log.Printf("[ValidateVerificationCode] Maximum attempts reached, locked out for %s", locked)
+		default:
+			log.Printf("[ValidateVerificationCode] Code consumed or replaced concurrently - code EXPIRED")
+			msg = constant.MsgExpired
+			status = pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_EXPIRED
 		}
 	} else {
 		log.Printf("[ValidateVerificationCode] No verification info found - code EXPIRED")
 		msg = constant.MsgExpired
 		status = pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_EXPIRED
+
+		// With nothing pending to charge, a guess may still be aimed at a
+		// bypass code, so it counts against the client. Counting it against
+		// the identity would let anyone lock its owner out.
+		if req.ClientIp != "" {
+			locked, err = s.countFailedGuess(ctx, codeAttemptScope, ipQuotaKey(req.ClientIp))
+			if err != nil {
+				log.Printf("[ValidateVerificationCode] ERROR counting failed guess: %v", err)
+				return nil, err
+			}
+		}
+		if locked > 0 {
+			log.Printf("[ValidateVerificationCode] Too many guesses without a code from %s, locked out for %s", req.ClientIp, locked)
+			msg = constant.MsgLockedOut
+			status = pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_LOCKED
+		}
+	}
+
+	if status != pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_VALID {
+		// A phone range entry covers many identities, so wrong guesses at
+		// any of them add up against the entry itself.
+		for _, name := range s.bypass.Covering(req.PhoneOrEmail, time.Now()) {
+			entryLocked, err := s.countFailedGuess(ctx, codeAttemptScope, bypassEntryIdentity(name))
+			if err != nil {
+				return nil, err
+			}
+			if entryLocked > 0 {
+				log.Printf("[ValidateVerificationCode] Bypass entry %s locked out for %s", name, entryLocked)
+			}
+		}
+	}
+
+	res := &pb.ValidateVerificationCodeResponse{Status: status, Msg: string(msg)}
+
+	if status == pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_INVALID {
+		res.AttemptsRemaining = int32(max(s.cfg.MaxAttempts-attempt, 0))
+		if !found.ExpiresAt.IsZero() {
+			res.ExpiresAt = found.ExpiresAt.Unix()
+		}
+	}
+
+	if status != pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_VALID {
+		wait, err := s.repo.RateLimitWait(ctx, []repository.RateLimitScope{s.identityRateLimitScope(req.PhoneOrEmail)})
+		if err != nil {
+			return nil, err
+		}
+		res.RetryAfterSeconds = durationToSeconds(max(wait, locked))
 	}
 
-	log.Printf("[ValidateVerificationCode] FINAL RESULT - Status: %v, Msg: %s", status, msg)
-	return &pb.ValidateVerificationCodeResponse{Status: status, Msg: string(msg)}, nil
+	log.Printf("[ValidateVerificationCode] FINAL RESULT - Status: %v, Msg: %s, Attempts remaining: %d", status, msg, res.AttemptsRemaining)
+	return res, nil
+}
+
+// codeAttemptScope counts wrong verification codes guessed while no code is
+// pending, which may be aimed at bypass codes, per client and bypass entry.
+const codeAttemptScope = "code"
+
+// bypassEntryIdentity is the identity under which wrong guesses at a bypass
+// entry are counted and the entry is locked out.
+func bypassEntryIdentity(name string) string {
+	return "bypass-entry:" + name
+}
+
+// countFailedGuess records a wrong guess by identity that no pending code
+// keeps track of. Past MaxAttempts such guesses it locks identity out as a
+// MAXIMUM_ATTEMPTS would, and returns the lockout.
+func (s *Server) countFailedGuess(ctx context.Context, scope, identity string) (time.Duration, error) {
+	failures, err := s.repo.RecordFailedAttempt(ctx, scope, identity, s.cfg.FailedAttemptWindow)
+	if err != nil {
+		return 0, err
+	}
+
+	if failures <= s.cfg.MaxAttempts {
+		return 0, nil
+	}
+
+	err = s.repo.ClearFailedAttempts(ctx, scope, identity)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.repo.LockIdentity(ctx, identity, s.cfg.LockoutDurations, s.cfg.LockoutResetAfter)
+}
+
+// rateLimitScopes lists the send quotas a generation request counts against.
+// Quotas are shared by all purposes of an identity.
+func (s *Server) rateLimitScopes(req *pb.GenerateVerificationCodeRequest) []repository.RateLimitScope {
+	return append([]repository.RateLimitScope{s.identityRateLimitScope(req.PhoneOrEmail)}, s.sharedRateLimitScopes(req)...)
+}
+
+// sharedRateLimitScopes lists the quotas of rateLimitScopes that other
+// identities count against too.
+func (s *Server) sharedRateLimitScopes(req *pb.GenerateVerificationCodeRequest) []repository.RateLimitScope {
+	var scopes []repository.RateLimitScope
+
+	if req.ClientIp != "" {
+		scopes = append(scopes, repository.RateLimitScope{Key: ipQuotaKey(req.ClientIp), Limits: s.limits.ip})
+	}
+
+	if !util.IsEmail(req.PhoneOrEmail) {
+		scopes = append(scopes, s.countryRateLimitScopes(req.PhoneOrEmail)...)
+	}
+
+	return scopes
+}
+
+// countryRateLimitScopes returns the quota of the country calling code of
+// phoneNumber, if it has one.
+func (s *Server) countryRateLimitScopes(phoneNumber string) []repository.RateLimitScope {
+	cc := phone.CallingCode(phoneNumber)
+	if cc == "" {
+		return nil
+	}
+	return []repository.RateLimitScope{{Key: "country:" + cc, Limits: s.limits.country}}
+}
+
+func (s *Server) identityRateLimitScope(phoneOrEmail string) repository.RateLimitScope {
+	return repository.RateLimitScope{Key: identityQuotaKey(phoneOrEmail, s.cfg.CanonicalEmailDomains), Limits: s.limits.identity}
+}
+
+// normalizeIdentity maps every spelling of a phone number to its E.164 form,
+// and of an email address to one with an ASCII domain, so codes, quotas and
+// lockouts are keyed the same way.
+func normalizeIdentity(phoneOrEmail, defaultRegion string) (string, error) {
+	if util.IsEmail(phoneOrEmail) {
+		addr, err := emailaddr.Parse(phoneOrEmail)
+		if err != nil {
+			return "", err
+		}
+		return addr.String(), nil
+	}
+
+	num, err := phone.Parse(phoneOrEmail, defaultRegion)
+	if err != nil {
+		return "", err
+	}
+
+	return num.E164, nil
+}
+
+// identityQuotaKey keys email addresses in their canonical form so plus-tag
+// and dot variants can't multiply the quota.
+func identityQuotaKey(phoneOrEmail string, canonicalDomains []string) string {
+	if addr, err := emailaddr.Parse(phoneOrEmail); err == nil {
+		return "identity:" + addr.Canonical(canonicalDomains)
+	}
+	return "identity:" + phoneOrEmail
+}
+
+// smsQuotaPrefix separates SendSms quotas from those of codes.
+const smsQuotaPrefix = "sms:"
+
+// ipQuotaKey keys an address in its canonical form, so every spelling of it
+// shares one quota. IPv6 addresses are keyed by their /64, which a single
+// subscriber usually holds whole.
+func ipQuotaKey(ip string) string {
+	addr := net.ParseIP(ip)
+	switch {
+	case addr == nil:
+		return "ip:" + ip
+	case addr.To4() != nil:
+		return "ip:" + addr.To4().String()
+	default:
+		return "ip:" + addr.Mask(net.CIDRMask(64, 128)).String() + "/64"
+	}
+}
+
+// durationToSeconds rounds d up so clients never retry too early.
+func durationToSeconds(d time.Duration) int32 {
+	return int32((d + time.Second - 1) / time.Second)
+}
+
+// resolveCodeSettings checks the request's purpose, client IP and mode, then
+// merges its code options with the server defaults and checks them against
+// the supported bounds.
+func (s *Server) resolveCodeSettings(req *pb.GenerateVerificationCodeRequest) (int, string, time.Duration, error) {
+	if !purposePattern.MatchString(req.Purpose) {
+		return 0, "", 0, fmt.Errorf("invalid purpose: %q", req.Purpose)
+	}
+
+	length := s.cfg.CodeLength
+	if req.CodeLength != 0 {
+		length = int(req.CodeLength)
+	}
+	if length < minCodeLength || length > maxCodeLength {
+		return 0, "", 0, fmt.Errorf("code length %d out of range [%d, %d]", length, minCodeLength, maxCodeLength)
+	}
+
+	if req.ClientIp != "" && net.ParseIP(req.ClientIp) == nil {
+		return 0, "", 0, fmt.Errorf("invalid client ip: %q", req.ClientIp)
+	}
+
+	switch req.Mode {
+	case pb.VerificationMode_VERIFICATION_MODE_CODE:
+	case pb.VerificationMode_VERIFICATION_MODE_LINK:
+		// SMS vendors only carry a code through their templates.
+		if !util.IsEmail(req.PhoneOrEmail) {
+			return 0, "", 0, fmt.Errorf("link verification requires an email address")
+		}
+		if req.LinkTemplate == "" {
+			return 0, "", 0, fmt.Errorf("link verification requires a link template")
+		}
+	default:
+		return 0, "", 0, fmt.Errorf("unsupported verification mode: %s", req.Mode)
+	}
+
+	alphabetName := strings.ToUpper(s.cfg.CodeAlphabet)
+	if req.CodeAlphabet != pb.CodeAlphabet_CODE_ALPHABET_UNSPECIFIED {
+		alphabetName = strings.TrimPrefix(req.CodeAlphabet.String(), "CODE_ALPHABET_")
+	}
+
+	var alphabet string
+	switch alphabetName {
+	case "NUMERIC":
+		alphabet = numericAlphabet
+	case "ALPHANUMERIC":
+		alphabet = alphanumericAlphabet
+	default:
+		return 0, "", 0, fmt.Errorf("unsupported code alphabet: %s", alphabetName)
+	}
+
+	ttl := s.cfg.CodeTtl
+	if req.TtlSeconds != 0 {
+		ttl = time.Duration(req.TtlSeconds) * time.Second
+	}
+	if ttl < minCodeTtl || ttl > maxCodeTtl {
+		return 0, "", 0, fmt.Errorf("code ttl %s out of range [%s, %s]", ttl, minCodeTtl, maxCodeTtl)
+	}
+
+	return length, alphabet, ttl, nil
+}
+
+// ValidateVerificationToken consumes a token issued by a
+// VERIFICATION_MODE_LINK generation. A token resolves at most once, whether or
+// not it turns out to be valid.
+func (s *Server) ValidateVerificationToken(ctx context.Context, req *pb.ValidateVerificationTokenRequest) (*pb.ValidateVerificationTokenResponse, error) {
+	res := &pb.ValidateVerificationTokenResponse{Status: pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_EXPIRED, Msg: string(constant.MsgExpired)}
+
+	if req.Token == "" || !purposePattern.MatchString(req.Purpose) {
+		return nil, status.Errorf(codes.InvalidArgument, "token and a valid purpose are required")
+	}
+
+	ref, err := s.repo.ConsumeVerificationToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if ref == nil {
+		log.Printf("[ValidateVerificationToken] Token unknown, used or expired")
+		return res, nil
+	}
+
+	if ref.Purpose != req.Purpose {
+		log.Printf("[ValidateVerificationToken] Token for %s issued for purpose %q, presented for %q", ref.PhoneOrEmail, ref.Purpose, req.Purpose)
+		res.Status = pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_INVALID
+		res.Msg = string(constant.MsgInvalid)
+		return res, nil
+	}
+
+	found, err := s.repo.GetVerificationInfo(ctx, ref.PhoneOrEmail, ref.Purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	// A newer code or link for the same identity replaces the record, which
+	// invalidates older links.
+	if found == nil || !s.repo.MatchVerificationCode(found, req.Token) {
+		log.Printf("[ValidateVerificationToken] Token for %s superseded or expired", ref.PhoneOrEmail)
+		return res, nil
+	}
+
+	result, _, err := s.repo.RecordVerificationAttempt(ctx, ref.PhoneOrEmail, ref.Purpose, found, true, s.cfg.MaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	if result != repository.AttemptValid {
+		log.Printf("[ValidateVerificationToken] Token for %s consumed concurrently", ref.PhoneOrEmail)
+		return res, nil
+	}
+
+	res.Status = pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_VALID
+	res.Msg = string(constant.MsgValid)
+	res.PhoneOrEmail = ref.PhoneOrEmail
+	res.Purpose = ref.Purpose
+
+	return res, nil
 }
 
 func (s *Server) SendEmailWithAttachment(ctx context.Context, req *pb.SendEmailWithAttachmentRequest) (*pb.SendEmailWithAttachmentResponse, error) {
@@ -224,7 +1122,7 @@ func (s *Server) SendEmailWithAttachment(ctx context.Context, req *pb.SendEmailW
 
 	mailVendor, err := s.resolveEmailVendor(req.EmailConfig)
 	if err != nil {
-		return nil, err
+		return nil, deliveryStatus(err)
 	}
 
 	err = mailVendor.SendEmailWithAttachment(req.To, req.Bcc, req.Subject, req.Message, attachments)
@@ -236,13 +1134,73 @@ func (s *Server) SendEmailWithAttachment(ctx context.Context, req *pb.SendEmailW
 	return &pb.SendEmailWithAttachmentResponse{Success: true}, nil
 }
 
+func (s *Server) SendSms(ctx context.Context, req *pb.SendSmsRequest) (*pb.SendSmsResponse, error) {
+	if util.IsEmail(req.PhoneNumber) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid phone_number: %q", req.PhoneNumber)
+	}
+
+	phoneNumber, err := normalizeIdentity(req.PhoneNumber, s.cfg.DefaultPhoneRegion)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid phone_number: %v", err)
+	}
+
+	if len(req.TemplateIds) == 0 && req.Text == "" {
+		return nil, status.Error(codes.InvalidArgument, "template_ids or text is required")
+	}
+
+	scopes := []repository.RateLimitScope{{Key: smsQuotaPrefix + phoneNumber, Limits: s.limits.sms}}
+	for _, scope := range s.countryRateLimitScopes(phoneNumber) {
+		scope.Key = smsQuotaPrefix + scope.Key
+		scopes = append(scopes, scope)
+	}
+
+	allowed, retryAfter, err := s.repo.ConsumeRateLimits(ctx, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		log.Printf("[SendSms] Send quota exhausted for %s, retry after %s", phoneNumber, retryAfter)
+		return nil, status.Errorf(codes.ResourceExhausted, "sending too frequently, retry after %d seconds", durationToSeconds(retryAfter))
+	}
+
+	msg := sms.Message{TemplateIds: req.TemplateIds, Params: req.TemplateParams, Text: req.Text}
+	receipt, err := s.smsVendor.SendSms(phoneNumber, &msg)
+
+	send := repository.SendRecord{
+		PhoneOrEmail:  phoneNumber,
+		Channel:       channelName(pb.Channel_CHANNEL_SMS),
+		Vendor:        smsReceiptVendor(receipt, err),
+		SentAt:        time.Now(),
+		Failed:        err != nil,
+		Transactional: true,
+	}
+	if err := s.repo.RecordSend(ctx, &send); err != nil {
+		log.Printf("[SendSms] Failed to record send to %s: %v", phoneNumber, err)
+	}
+
+	if err != nil {
+		log.Printf("[SendSms] Failed to send to %s: %v", phoneNumber, err)
+		return nil, deliveryStatus(err)
+	}
+
+	log.Printf("[SendSms] Sent message %s to %s through %s", receipt.MessageID, phoneNumber, receipt.Vendor)
+
+	return &pb.SendSmsResponse{Success: true, Vendor: receipt.Vendor, MessageId: receipt.MessageID}, nil
+}
+
 func (s *Server) resolveEmailVendor(cfg *pb.EmailConfig) (email.EmailVendor, error) {
 	emailCfg, err := translateEmailConfig(cfg)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", errInvalidDelivery, err)
 	}
 
-	return email.NewVendor(emailCfg)
+	vendor, err := email.NewVendor(emailCfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidDelivery, err)
+	}
+
+	return vendor, nil
 }
 
 func translateEmailConfig(cfg *pb.EmailConfig) (email.Config, error) {
@@ -264,7 +1222,15 @@ func translateEmailConfig(cfg *pb.EmailConfig) (email.Config, error) {
 	}
 }
 
-func templateToMessage(msgTemplate string, code string) (string, error) {
+// messageData is what message and link templates can reference.
+type messageData struct {
+	Code   string
+	Token  string
+	Link   string
+	Params map[string]string
+}
+
+func templateToMessage(msgTemplate string, data messageData) (string, error) {
 	tmpl, err := template.New("message").Parse(msgTemplate)
 
 	if err != nil {
@@ -272,7 +1238,7 @@ func templateToMessage(msgTemplate string, code string) (string, error) {
 	}
 
 	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, struct{ Code string }{code})
+	err = tmpl.Execute(&buf, data)
 
 	if err != nil {
 		return "", err
@@ -0,0 +1,422 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/more-than-code/messaging/bypass"
+	"github.com/more-than-code/messaging/pb"
+	"github.com/more-than-code/messaging/repository"
+	sms "github.com/more-than-code/messaging/sms-vendor"
+	"github.com/more-than-code/messaging/voice-vendor"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestServer returns a Server with the default config, backed by an in
+// memory Redis and issuing code.
+func newTestServer(t *testing.T, code string) *Server {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	t.Setenv("REDIS_URI", mr.Addr())
+	t.Setenv("VERIFICATION_CODE_SECRET", "test-secret")
+
+	var cfg ServerConfig
+	if err := envconfig.Process("", &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	limits, err := parseRateLimits(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registry, err := bypass.Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := repository.NewRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Server{codeGen: FixedCodeGenerator{Code: code}, bypass: registry, limits: limits, repo: repo, cfg: &cfg}
+}
+
+// testSmsVendor accepts every message, or fails it with err when set.
+type testSmsVendor struct {
+	err   error
+	codes []string
+	texts []string
+}
+
+func (v *testSmsVendor) SendCode(phoneNumber string, params sms.TemplateParams) (*sms.Receipt, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	v.codes = append(v.codes, params["code"])
+	return &sms.Receipt{Vendor: "TEST", MessageID: "code-1"}, nil
+}
+
+func (v *testSmsVendor) SendSms(phoneNumber string, msg *sms.Message) (*sms.Receipt, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	v.texts = append(v.texts, msg.Text)
+	return &sms.Receipt{Vendor: "TEST", MessageID: "sms-1"}, nil
+}
+
+func TestGenerateVerificationCodeVoice(t *testing.T) {
+	s := newTestServer(t, "4821")
+	vendor := &voice.FakeVendor{}
+	s.voiceVendor = vendor
+
+	res, err := s.GenerateVerificationCode(context.Background(), &pb.GenerateVerificationCodeRequest{
+		PhoneOrEmail: "+14155550100",
+		Channel:      pb.Channel_CHANNEL_VOICE,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_DONE {
+		t.Fatalf("got status %s", res.Status)
+	}
+
+	want := []voice.Call{{PhoneNumber: "+14155550100", Script: "Your verification code is 4, 8, 2, 1."}}
+	if calls := vendor.Calls(); len(calls) != 1 || calls[0] != want[0] {
+		t.Errorf("got calls %+v, want %+v", calls, want)
+	}
+
+	valid, err := s.ValidateVerificationCode(context.Background(), &pb.ValidateVerificationCodeRequest{PhoneOrEmail: "+14155550100", VerificationCode: "4821"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid.Status != pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_VALID {
+		t.Errorf("got validation status %s", valid.Status)
+	}
+}
+
+func TestSmsTemplateParams(t *testing.T) {
+	custom := map[string]string{"code": "0000", "product": "Evil", "minutes": "5"}
+
+	for _, product := range []string{"Acme", ""} {
+		params := smsTemplateParams(custom, "4821", product)
+		if params["code"] != "4821" || params["product"] != product || params["minutes"] != "5" {
+			t.Errorf("product %q: got %v", product, params)
+		}
+	}
+}
+
+func TestCheckLockoutDurations(t *testing.T) {
+	tests := []struct {
+		durations []time.Duration
+		ok        bool
+	}{
+		{nil, true},
+		{[]time.Duration{15 * time.Minute, time.Hour}, true},
+		{[]time.Duration{15 * time.Minute, 0}, false},
+		{[]time.Duration{-time.Minute}, false},
+	}
+
+	for _, tt := range tests {
+		if err := checkLockoutDurations(tt.durations); (err == nil) != tt.ok {
+			t.Errorf("checkLockoutDurations(%v) = %v", tt.durations, err)
+		}
+	}
+}
+
+func TestCheckCodeDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ServerConfig
+		ok   bool
+	}{
+		{"numeric", ServerConfig{CodeLength: 4, CodeAlphabet: "NUMERIC", CodeTtl: 5 * time.Minute}, true},
+		{"lowercase alphabet", ServerConfig{CodeLength: 6, CodeAlphabet: "alphanumeric", CodeTtl: time.Hour}, true},
+		{"short", ServerConfig{CodeLength: 3, CodeAlphabet: "NUMERIC", CodeTtl: 5 * time.Minute}, false},
+		{"long", ServerConfig{CodeLength: 13, CodeAlphabet: "NUMERIC", CodeTtl: 5 * time.Minute}, false},
+		{"unknown alphabet", ServerConfig{CodeLength: 4, CodeAlphabet: "HEX", CodeTtl: 5 * time.Minute}, false},
+		{"no ttl", ServerConfig{CodeLength: 4, CodeAlphabet: "NUMERIC"}, false},
+	}
+
+	for _, tt := range tests {
+		if err := checkCodeDefaults(&tt.cfg); (err == nil) != tt.ok {
+			t.Errorf("%s: got %v", tt.name, err)
+		}
+	}
+}
+
+func TestSendSmsQuota(t *testing.T) {
+	t.Setenv("RATE_LIMITS_SMS", "2/1h")
+	s := newTestServer(t, "4821")
+	vendor := &testSmsVendor{}
+	s.smsVendor = vendor
+	ctx := context.Background()
+
+	req := &pb.SendSmsRequest{PhoneNumber: "+14155550100", Text: "Your order has shipped"}
+	for i := 0; i < 2; i++ {
+		if _, err := s.SendSms(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := s.SendSms(ctx, req)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("got %v, want ResourceExhausted", err)
+	}
+	if len(vendor.texts) != 2 {
+		t.Errorf("sent %d messages", len(vendor.texts))
+	}
+
+	// Notifications don't use up the quota of codes.
+	res, err := s.GenerateVerificationCode(ctx, &pb.GenerateVerificationCodeRequest{PhoneOrEmail: "+14155550100"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_DONE {
+		t.Errorf("got status %s", res.Status)
+	}
+
+	sends, err := s.repo.ListRecentSends(ctx, "+14155550100", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sends) != 3 || sends[0].Transactional || !sends[1].Transactional || sends[1].Vendor != "TEST" {
+		t.Errorf("got recent sends %+v", sends)
+	}
+}
+
+func TestGenerateVerificationCodeResendAfterFailure(t *testing.T) {
+	s := newTestServer(t, "4821")
+	vendor := &testSmsVendor{err: &sms.SendError{Vendor: "TEST", Message: "upstream down", Retryable: true}}
+	s.smsVendor = vendor
+	ctx := context.Background()
+
+	req := &pb.GenerateVerificationCodeRequest{PhoneOrEmail: "+14155550100"}
+	_, err := s.GenerateVerificationCode(ctx, req)
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("got %v, want Unavailable", err)
+	}
+
+	res, err := s.GenerateVerificationCode(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_NEEDING_RESENDING || res.RetryAfterSeconds != 0 {
+		t.Fatalf("got %s, retry after %d", res.Status, res.RetryAfterSeconds)
+	}
+
+	// The failed delivery spent the identity quota; the resend doesn't need
+	// it again.
+	vendor.err = nil
+	res, err = s.GenerateVerificationCode(ctx, &pb.GenerateVerificationCodeRequest{PhoneOrEmail: "+14155550100", Resend: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_DONE {
+		t.Fatalf("got status %s", res.Status)
+	}
+	if len(vendor.codes) != 1 || vendor.codes[0] != "4821" {
+		t.Errorf("sent codes %v", vendor.codes)
+	}
+
+	// Once delivered, the identity quota applies again.
+	res, err = s.GenerateVerificationCode(ctx, &pb.GenerateVerificationCodeRequest{PhoneOrEmail: "+14155550100", Resend: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_SENDING_TOO_FREQUENTLY {
+		t.Errorf("got status %s", res.Status)
+	}
+}
+
+func TestGenerateVerificationCodeNeedingResendingRetryAfter(t *testing.T) {
+	t.Setenv("RATE_LIMITS_IP", "1/1m")
+	s := newTestServer(t, "4821")
+	s.smsVendor = &testSmsVendor{err: &sms.SendError{Vendor: "TEST", Retryable: true}}
+	ctx := context.Background()
+
+	req := &pb.GenerateVerificationCodeRequest{PhoneOrEmail: "+14155550100", ClientIp: "192.0.2.1"}
+	if _, err := s.GenerateVerificationCode(ctx, req); status.Code(err) != codes.Unavailable {
+		t.Fatalf("got %v, want Unavailable", err)
+	}
+
+	res, err := s.GenerateVerificationCode(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_NEEDING_RESENDING || res.RetryAfterSeconds < 59 {
+		t.Errorf("got %s, retry after %d", res.Status, res.RetryAfterSeconds)
+	}
+}
+
+func TestGenerateVerificationCodeVoiceAfterFailedSms(t *testing.T) {
+	s := newTestServer(t, "4821")
+	s.smsVendor = &testSmsVendor{err: &sms.SendError{Vendor: "TEST", Retryable: true}}
+	vendor := &voice.FakeVendor{}
+	s.voiceVendor = vendor
+	ctx := context.Background()
+
+	_, err := s.GenerateVerificationCode(ctx, &pb.GenerateVerificationCodeRequest{PhoneOrEmail: "+14155550100"})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("got %v, want Unavailable", err)
+	}
+
+	// "Call me instead" reads out the code the SMS failed to deliver.
+	res, err := s.GenerateVerificationCode(ctx, &pb.GenerateVerificationCodeRequest{
+		PhoneOrEmail: "+14155550100",
+		Channel:      pb.Channel_CHANNEL_VOICE,
+		Resend:       true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_DONE {
+		t.Fatalf("got status %s", res.Status)
+	}
+
+	if calls := vendor.Calls(); len(calls) != 1 || calls[0].Script != "Your verification code is 4, 8, 2, 1." {
+		t.Errorf("got calls %+v", calls)
+	}
+
+	sends, err := s.repo.ListRecentSends(ctx, "+14155550100", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sends) != 2 || sends[0].Channel != "voice" || !sends[0].Resend || sends[1].Channel != "sms" || !sends[1].Failed {
+		t.Errorf("got recent sends %+v", sends)
+	}
+}
+
+func TestValidateVerificationCodeWithoutPendingCode(t *testing.T) {
+	s := newTestServer(t, "4821")
+	s.smsVendor = &testSmsVendor{}
+	ctx := context.Background()
+
+	validate := func(clientIp string) *pb.ValidateVerificationCodeResponse {
+		t.Helper()
+		res, err := s.ValidateVerificationCode(ctx, &pb.ValidateVerificationCodeRequest{PhoneOrEmail: "+14155550100", VerificationCode: "0000", ClientIp: clientIp})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	// Guesses without a client never lock the identity's owner out.
+	for i := 0; i < 2*s.cfg.MaxAttempts; i++ {
+		if res := validate(""); res.Status != pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_EXPIRED {
+			t.Fatalf("guess %d: got status %s", i, res.Status)
+		}
+	}
+
+	// A guessing client is locked out after MaxAttempts guesses, alone.
+	for i := 0; i < s.cfg.MaxAttempts; i++ {
+		if res := validate("192.0.2.1"); res.Status != pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_EXPIRED {
+			t.Fatalf("guess %d: got status %s", i, res.Status)
+		}
+	}
+	if res := validate("192.0.2.1"); res.Status != pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_LOCKED || res.RetryAfterSeconds == 0 {
+		t.Fatalf("got %s, retry after %d", res.Status, res.RetryAfterSeconds)
+	}
+	if res := validate("192.0.2.1"); res.Status != pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_LOCKED {
+		t.Errorf("got status %s for a locked client", res.Status)
+	}
+	if res := validate("192.0.2.2"); res.Status != pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_EXPIRED {
+		t.Errorf("got status %s for another client", res.Status)
+	}
+
+	res, err := s.GenerateVerificationCode(ctx, &pb.GenerateVerificationCodeRequest{PhoneOrEmail: "+14155550100"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_DONE {
+		t.Errorf("got generation status %s", res.Status)
+	}
+}
+
+func TestTotpRequiresIdentity(t *testing.T) {
+	s := newTestServer(t, "4821")
+	ctx := context.Background()
+
+	if _, err := s.ConfirmTotpEnrollment(ctx, &pb.ConfirmTotpEnrollmentRequest{Code: "123456"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ConfirmTotpEnrollment: got %v, want InvalidArgument", err)
+	}
+	if _, err := s.ValidateTotp(ctx, &pb.ValidateTotpRequest{Code: "123456"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ValidateTotp: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestResetRateLimitsClearsClientLockout(t *testing.T) {
+	s := newTestServer(t, "4821")
+	admin := &AdminServer{repo: s.repo, cfg: s.cfg}
+	ctx := context.Background()
+
+	req := &pb.ValidateVerificationCodeRequest{PhoneOrEmail: "+14155550100", VerificationCode: "0000", ClientIp: "192.0.2.1"}
+	for i := 0; i <= s.cfg.MaxAttempts; i++ {
+		if _, err := s.ValidateVerificationCode(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reset, err := admin.ResetRateLimits(ctx, &pb.ResetRateLimitsRequest{ClientIp: "192.0.2.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reset.LockoutCleared {
+		t.Error("client lockout not reported cleared")
+	}
+
+	res, err := s.ValidateVerificationCode(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != pb.VerificationCodeValidationStatus_VERIFICATION_CODE_VALIDATION_STATUS_EXPIRED {
+		t.Errorf("got status %s after reset", res.Status)
+	}
+}
+
+func TestIpQuotaKey(t *testing.T) {
+	tests := []struct {
+		ips  []string
+		want string
+	}{
+		{[]string{"192.0.2.1", "::ffff:192.0.2.1"}, "ip:192.0.2.1"},
+		{[]string{"2001:db8::1", "2001:DB8:0::1", "2001:0db8:0000:0000:ffff::2"}, "ip:2001:db8::/64"},
+	}
+
+	for _, tt := range tests {
+		for _, ip := range tt.ips {
+			if got := ipQuotaKey(ip); got != tt.want {
+				t.Errorf("ipQuotaKey(%q) = %q, want %q", ip, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestIpQuotaSharedAcrossSpellings(t *testing.T) {
+	t.Setenv("RATE_LIMITS_IP", "1/1h")
+	s := newTestServer(t, "4821")
+	s.smsVendor = &testSmsVendor{}
+	ctx := context.Background()
+
+	res, err := s.GenerateVerificationCode(ctx, &pb.GenerateVerificationCodeRequest{PhoneOrEmail: "+14155550100", ClientIp: "2001:db8::1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_DONE {
+		t.Fatalf("got status %s", res.Status)
+	}
+
+	res, err = s.GenerateVerificationCode(ctx, &pb.GenerateVerificationCodeRequest{PhoneOrEmail: "+14155550101", ClientIp: "2001:DB8:0::1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != pb.VerificationCodeGenerationStatus_VERIFICATION_CODE_GENERATION_STATUS_SENDING_TOO_FREQUENTLY {
+		t.Errorf("got status %s for another spelling of the address", res.Status)
+	}
+}
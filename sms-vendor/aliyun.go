@@ -1,16 +1,25 @@
 package sms
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
+
+	"github.com/more-than-code/messaging/phone"
 
 	"github.com/alibabacloud-go/tea/tea"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk"
+	aliyunErrors "github.com/aliyun/alibaba-cloud-sdk-go/sdk/errors"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/kelseyhightower/envconfig"
 )
 
+// AliyunVendor sends to mainland China numbers through the template-based
+// Dysms SendSms API and to all others through the Globe API.
 type AliyunVendor struct {
+	client      *sdk.Client
 	clientGlobe *sdk.Client
 	cfg         AliyunConfig
 }
@@ -22,13 +31,137 @@ type AliyunConfig struct {
 	TemplateCode      string `envconfig:"SMS_TEMPLATE_CODE"`
 	AppGlobeKeyId     string `envconfig:"SMS_GLOBE_ACCESS_KEY_ID"`
 	AppGlobeKeySecret string `envconfig:"SMS_GLOBE_ACCESS_KEY_SECRET"`
+	// GlobeMessage is the text sent through the Globe API, which takes no
+	// templates. {code}, {product} and other template params are replaced.
+	GlobeMessage string `envconfig:"SMS_GLOBE_MESSAGE" default:"Your verification code is {code}"`
+}
+
+func CreateClient(accessKeyId string, accessKeySecret string) (*sdk.Client, error) {
+	return sdk.NewClientWithAccessKey("cn-hangzhou", accessKeyId, accessKeySecret)
 }
 
 func CreateClientGlobe(accessKeyId string, accessKeySecret string) (*sdk.Client, error) {
 	return sdk.NewClientWithAccessKey("ap-southeast-1", accessKeyId, accessKeySecret)
 }
 
-func (v *AliyunVendor) SendCodeGlobe(phoneNumber, msg string) error {
+func NewAliyunVendor() (*AliyunVendor, error) {
+	var cfg AliyunConfig
+	err := envconfig.Process("", &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &AliyunVendor{cfg: cfg}
+
+	if cfg.AppKeyId != "" {
+		v.client, err = CreateClient(*tea.String(cfg.AppKeyId), *tea.String(cfg.AppKeySecret))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.AppGlobeKeyId != "" {
+		v.clientGlobe, err = CreateClientGlobe(*tea.String(cfg.AppGlobeKeyId), *tea.String(cfg.AppGlobeKeySecret))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if v.client == nil && v.clientGlobe == nil {
+		return nil, errors.New("aliyun needs SMS_ACCESS_KEY_ID or SMS_GLOBE_ACCESS_KEY_ID")
+	}
+
+	return v, nil
+}
+
+func (v *AliyunVendor) SendCode(phoneNumber string, params TemplateParams) (*Receipt, error) {
+	return v.sendCode(phoneNumber, "", params)
+}
+
+// sendCode takes template as the template code of mainland China numbers;
+// others get the configured globe message.
+func (v *AliyunVendor) sendCode(phoneNumber, template string, params TemplateParams) (*Receipt, error) {
+	if template == "" {
+		template = v.cfg.TemplateCode
+	}
+
+	if phone.CountryCode(phoneNumber) == 86 {
+		return v.sendDomestic(strings.TrimPrefix(phoneNumber, "+86"), template, params)
+	}
+
+	return v.SendCodeGlobe(phoneNumber, params.Render(v.cfg.GlobeMessage))
+}
+
+// SendSms sends a template to mainland China numbers, which only take
+// templates, and text to all others.
+func (v *AliyunVendor) SendSms(phoneNumber string, msg *Message) (*Receipt, error) {
+	if phone.CountryCode(phoneNumber) == 86 {
+		templateCode, ok := msg.TemplateIds[ProviderAliyun]
+		if !ok {
+			return nil, fmt.Errorf("%w: aliyun only sends templates to +86 numbers", ErrUnsupportedMessage)
+		}
+		return v.sendDomestic(strings.TrimPrefix(phoneNumber, "+86"), templateCode, msg.Params)
+	}
+
+	text, err := msg.render()
+	if err != nil {
+		return nil, err
+	}
+	return v.SendCodeGlobe(phoneNumber, text)
+}
+
+// sendDomestic sends a template to a mainland China number given without its
+// country code.
+func (v *AliyunVendor) sendDomestic(phoneNumber, templateCode string, params TemplateParams) (*Receipt, error) {
+	if v.client == nil {
+		return nil, fmt.Errorf("%w: aliyun domestic sms is not configured", ErrUnsupportedMessage)
+	}
+
+	templateParam, err := params.JSON()
+	if err != nil {
+		return nil, err
+	}
+
+	request := requests.NewCommonRequest()
+	request.Method = "POST"
+	request.Scheme = "https"
+	request.Domain = "dysmsapi.aliyuncs.com"
+	request.Version = "2017-05-25"
+	request.ApiName = "SendSms"
+	request.QueryParams["PhoneNumbers"] = phoneNumber
+	request.QueryParams["SignName"] = v.cfg.SignName
+	request.QueryParams["TemplateCode"] = templateCode
+	request.QueryParams["TemplateParam"] = templateParam
+
+	response, err := v.client.ProcessCommonRequest(request)
+	if err != nil {
+		log.Printf("aliyun: SendSms to %s failed: %v", phoneNumber, err)
+		return nil, aliyunSendError(err)
+	}
+
+	var result struct {
+		Code    string
+		Message string
+		BizId   string
+	}
+	err = json.Unmarshal(response.GetHttpContentBytes(), &result)
+	if err != nil {
+		return nil, fmt.Errorf("parsing aliyun response: %w", err)
+	}
+
+	if result.Code != "OK" {
+		log.Printf("aliyun: SendSms to %s rejected: %s %s", phoneNumber, result.Code, result.Message)
+		return nil, aliyunRejection(result.Code, result.Message, result.BizId)
+	}
+
+	return &Receipt{Vendor: ProviderAliyun, MessageID: result.BizId}, nil
+}
+
+func (v *AliyunVendor) SendCodeGlobe(phoneNumber, msg string) (*Receipt, error) {
+	if v.clientGlobe == nil {
+		return nil, fmt.Errorf("%w: aliyun globe sms is not configured", ErrUnsupportedMessage)
+	}
+
 	request := requests.NewCommonRequest()
 	request.Method = "POST"
 	request.Scheme = "https"
@@ -36,27 +169,61 @@ func (v *AliyunVendor) SendCodeGlobe(phoneNumber, msg string) error {
 	request.Version = "2018-05-01"
 	request.ApiName = "SendMessageToGlobe"
 	request.QueryParams["RegionId"] = "ap-southeast-1"
-	request.QueryParams["To"] = phoneNumber
+	request.QueryParams["To"] = strings.TrimPrefix(phoneNumber, "+")
 	request.QueryParams["Message"] = msg
 
 	response, err := v.clientGlobe.ProcessCommonRequest(request)
-
-	fmt.Print(response.GetHttpContentString())
-
-	return err
-}
-
-func NewAliyunVendor() (*AliyunVendor, error) {
-	var cfg AliyunConfig
-	err := envconfig.Process("", &cfg)
 	if err != nil {
-		log.Fatal(err)
+		log.Printf("aliyun: SendMessageToGlobe to %s failed: %v", phoneNumber, err)
+		return nil, aliyunSendError(err)
 	}
 
-	clientGlobe, err := CreateClientGlobe(*tea.String(cfg.AppGlobeKeyId), *tea.String(cfg.AppGlobeKeySecret))
+	var result struct {
+		ResponseCode        string
+		ResponseDescription string
+		MessageId           string
+	}
+	err = json.Unmarshal(response.GetHttpContentBytes(), &result)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("parsing aliyun response: %w", err)
 	}
 
-	return &AliyunVendor{clientGlobe: clientGlobe, cfg: cfg}, nil
+	if result.ResponseCode != "OK" {
+		log.Printf("aliyun: SendMessageToGlobe to %s rejected: %s %s", phoneNumber, result.ResponseCode, result.ResponseDescription)
+		return nil, aliyunRejection(result.ResponseCode, result.ResponseDescription, result.MessageId)
+	}
+
+	return &Receipt{Vendor: ProviderAliyun, MessageID: result.MessageId}, nil
+}
+
+// aliyunRejectCodes are the response codes that blame the message rather than
+// the account or the service.
+var aliyunRejectCodes = map[string]bool{
+	"isv.MOBILE_NUMBER_ILLEGAL":       true,
+	"isv.MOBILE_COUNT_OVER_LIMIT":     true,
+	"isv.INVALID_PARAMETERS":          true,
+	"isv.INVALID_JSON_PARAM":          true,
+	"isv.PARAM_LENGTH_LIMIT":          true,
+	"isv.PARAM_NOT_SUPPORT_URL":       true,
+	"isv.TEMPLATE_MISSING_PARAMETERS": true,
+	"isv.BLACK_KEY_CONTROL_LIMIT":     true,
+	"isv.DAY_LIMIT_CONTROL":           true,
+	"isv.MONTH_LIMIT_CONTROL":         true,
+	"isv.SMS_CONTENT_ILLEGAL":         true,
+}
+
+func aliyunRejection(code, message, messageId string) *SendError {
+	return &SendError{Vendor: ProviderAliyun, Code: code, Message: message, Retryable: !aliyunRejectCodes[code], MessageID: messageId}
+}
+
+// aliyunSendError wraps a failed request. The SDK returns a ServerError with
+// a response code for any non-2xx status, and other errors when the service
+// can't be reached.
+func aliyunSendError(err error) *SendError {
+	var serverErr *aliyunErrors.ServerError
+	if errors.As(err, &serverErr) {
+		code := serverErr.ErrorCode()
+		return &SendError{Vendor: ProviderAliyun, Code: code, Message: serverErr.Message(), Retryable: !aliyunRejectCodes[code], Err: err}
+	}
+	return &SendError{Vendor: ProviderAliyun, Retryable: true, Err: err}
 }
@@ -2,7 +2,7 @@ package sms
 
 import (
 	"fmt"
-	"strings"
+	"log"
 
 	"github.com/byteplus-sdk/byteplus-sdk-golang/service/sms"
 	"github.com/kelseyhightower/envconfig"
@@ -32,50 +32,78 @@ func NewBytePlusVendor() (*BytePlusVendor, error) {
 	return &BytePlusVendor{cfg: cfg}, nil
 }
 
-func (v *BytePlusVendor) SendCode(phoneNumber, code string) error {
-	sms.DefaultInstance.Client.SetAccessKey(v.cfg.AccessKey)
-	sms.DefaultInstance.Client.SetSecretKey(v.cfg.SecretKey)
+func (v *BytePlusVendor) SendCode(phoneNumber string, params TemplateParams) (*Receipt, error) {
+	return v.sendCode(phoneNumber, "", params)
+}
 
-	var tempId string
-	if strings.HasPrefix(phoneNumber, "86") || strings.HasPrefix(phoneNumber, "+86") {
-		tempId = v.cfg.Template
-	} else {
-		tempId = v.cfg.Template
+func (v *BytePlusVendor) sendCode(phoneNumber, template string, params TemplateParams) (*Receipt, error) {
+	if template == "" {
+		template = v.cfg.Template
 	}
 
+	templateParam, err := params.JSON()
+	if err != nil {
+		return nil, err
+	}
+
+	i18nInstance := sms.NewInstanceI18n(base.RegionApSingapore)
+	i18nInstance.Client.SetAccessKey(v.cfg.AccessKey)
+	i18nInstance.Client.SetSecretKey(v.cfg.SecretKey)
+
 	req := &sms.SmsRequest{
 		SmsAccount:    v.cfg.Account,
-		TemplateID:    tempId,
-		TemplateParam: fmt.Sprintf(`{"code": "%s"}`, code),
+		TemplateID:    template,
+		TemplateParam: templateParam,
 		PhoneNumbers:  phoneNumber,
+		From:          v.cfg.Sender,
 		Tag:           "msgs",
 	}
-	result, statusCode, err := sms.DefaultInstance.Send(req)
-	fmt.Printf("result = %+v\n", result)
-	fmt.Printf("statusCode = %+v\n", statusCode)
-	fmt.Printf("err = %+v\n", err)
 
-	return err
+	return v.send(i18nInstance, req)
 }
 
-func (v *BytePlusVendor) SendCodeNProduct(phoneNumber, code, product string) error {
+func (v *BytePlusVendor) SendSms(phoneNumber string, msg *Message) (*Receipt, error) {
+	tempId, ok := msg.TemplateIds[ProviderBytePlus]
+	if !ok {
+		return nil, fmt.Errorf("%w: byteplus only sends templates", ErrUnsupportedMessage)
+	}
+
+	templateParam, err := msg.Params.JSON()
+	if err != nil {
+		return nil, err
+	}
+
 	i18nInstance := sms.NewInstanceI18n(base.RegionApSingapore)
 	i18nInstance.Client.SetAccessKey(v.cfg.AccessKey)
 	i18nInstance.Client.SetSecretKey(v.cfg.SecretKey)
 
 	req := &sms.SmsRequest{
 		SmsAccount:    v.cfg.Account,
-		TemplateID:    v.cfg.Template,
-		TemplateParam: fmt.Sprintf(`{"code": "%s", "product": "%s"}`, code, product),
+		TemplateID:    tempId,
+		TemplateParam: templateParam,
 		PhoneNumbers:  phoneNumber,
 		From:          v.cfg.Sender,
 		Tag:           "msgs",
 	}
-	result, statusCode, err := i18nInstance.Send(req)
-	fmt.Printf("result = %+v\n", result)
-	fmt.Printf("result.ResponseMetadata.Error = %+v\n", result.ResponseMetadata.Error)
-	fmt.Printf("statusCode = %+v\n", statusCode)
-	fmt.Printf("err = %+v\n", err)
 
-	return err
+	return v.send(i18nInstance, req)
+}
+
+func (v *BytePlusVendor) send(instance *sms.SMS, req *sms.SmsRequest) (*Receipt, error) {
+	result, statusCode, err := instance.Send(req)
+	if err != nil {
+		log.Printf("byteplus: SendSms to %s failed: %v", req.PhoneNumbers, err)
+		return nil, volcSendError(ProviderBytePlus, statusCode, err)
+	}
+
+	if rejection := result.ResponseMetadata.Error; rejection != nil {
+		log.Printf("byteplus: SendSms to %s rejected: %s %s", req.PhoneNumbers, rejection.Code, rejection.Message)
+		return nil, volcRejection(ProviderBytePlus, rejection.Code, rejection.Message)
+	}
+
+	receipt := &Receipt{Vendor: ProviderBytePlus}
+	if result.Result != nil && len(result.Result.MessageID) > 0 {
+		receipt.MessageID = result.Result.MessageID[0]
+	}
+	return receipt, nil
 }
@@ -0,0 +1,63 @@
+package sms
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// SendError is a send that a vendor rejected or couldn't be reached for.
+type SendError struct {
+	// Vendor is the provider name, e.g. VOLC.
+	Vendor string
+	// Code is the vendor's error code or status, e.g. isv.MOBILE_NUMBER_ILLEGAL.
+	Code    string
+	Message string
+	// Retryable means the vendor is at fault, e.g. an outage, throttling or
+	// bad credentials, so sending again or through another vendor may work.
+	// Otherwise the message itself was rejected, e.g. for an invalid number.
+	Retryable bool
+	// MessageID is set when the vendor assigned an ID before failing.
+	MessageID string
+	// Err is the underlying transport error, if any.
+	Err error
+}
+
+func (e *SendError) Error() string {
+	msg := e.Message
+	if msg == "" && e.Err != nil {
+		msg = e.Err.Error()
+	}
+	if msg == "" {
+		msg = "send failed"
+	}
+	if e.Code == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s (%s)", msg, e.Code)
+}
+
+func (e *SendError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryable reports whether err may go away by sending again, possibly
+// through another vendor. Errors other than SendErrors are retryable unless
+// they wrap ErrUnsupportedMessage.
+func IsRetryable(err error) bool {
+	var sendErr *SendError
+	if errors.As(err, &sendErr) {
+		return sendErr.Retryable
+	}
+	return !errors.Is(err, ErrUnsupportedMessage)
+}
+
+// httpRetryable reports whether an HTTP status blames the vendor rather than
+// the request. A zero status means no response.
+func httpRetryable(statusCode int) bool {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return statusCode < 400 || statusCode >= 500
+}
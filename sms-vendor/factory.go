@@ -0,0 +1,86 @@
+package sms
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NewVendor creates the SmsVendor for a provider name, configured from the
+// environment.
+func NewVendor(provider string) (SmsVendor, error) {
+	switch provider {
+	case ProviderVolc:
+		return NewVolcVendor()
+	case ProviderBytePlus:
+		return NewBytePlusVendor()
+	case ProviderAliyun:
+		return NewAliyunVendor()
+	case ProviderTwilio:
+		return NewTwilioVendor()
+	case ProviderSmpp:
+		return NewSmppVendor()
+	default:
+		return nil, fmt.Errorf("unsupported sms provider: %s", provider)
+	}
+}
+
+// templateSender is implemented by vendors that can send the code with a
+// template other than their configured one.
+type templateSender interface {
+	// sendCode sends the code with template, or with the configured
+	// template when it is empty.
+	sendCode(phoneNumber, template string, params TemplateParams) (*Receipt, error)
+}
+
+// VendorSpec names a provider and optionally the template it sends codes
+// with instead of the configured one.
+type VendorSpec struct {
+	Provider string `json:"provider"`
+	Template string `json:"template,omitempty"`
+}
+
+// VendorPool creates each provider's vendor once, so the chains it builds
+// share its connection and circuit breaker whatever template they send with:
+// an outage seen by one route opens the breaker for all of them.
+type VendorPool struct {
+	cfg     FailoverConfig
+	members map[string]*failoverMember
+}
+
+func NewVendorPool(cfg FailoverConfig) *VendorPool {
+	return &VendorPool{cfg: cfg, members: make(map[string]*failoverMember)}
+}
+
+// Chain chains the vendors of specs in failover order, creating those the
+// pool doesn't have yet.
+func (p *VendorPool) Chain(specs []VendorSpec) (*FailoverVendor, error) {
+	if len(specs) == 0 {
+		return nil, errors.New("failover needs at least one vendor")
+	}
+
+	f := &FailoverVendor{}
+	for _, spec := range specs {
+		m, ok := p.members[spec.Provider]
+		if !ok {
+			vendor, err := NewVendor(spec.Provider)
+			if err != nil {
+				return nil, err
+			}
+
+			m = &failoverMember{
+				name:    spec.Provider,
+				vendor:  vendor,
+				breaker: &breaker{threshold: max(p.cfg.BreakerThreshold, 1), cooldown: p.cfg.BreakerCooldown},
+			}
+			p.members[spec.Provider] = m
+		}
+
+		if _, ok := m.vendor.(templateSender); spec.Template != "" && !ok {
+			return nil, fmt.Errorf("sms provider %s doesn't take a template", spec.Provider)
+		}
+
+		f.links = append(f.links, failoverLink{failoverMember: m, template: spec.Template})
+	}
+
+	return f, nil
+}
@@ -0,0 +1,142 @@
+package sms
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+type FailoverConfig struct {
+	// BreakerThreshold consecutive errors open a vendor's circuit breaker.
+	BreakerThreshold int `envconfig:"SMS_BREAKER_THRESHOLD" default:"3"`
+	// BreakerCooldown is how long an open breaker skips its vendor before
+	// trying it again.
+	BreakerCooldown time.Duration `envconfig:"SMS_BREAKER_COOLDOWN" default:"1m"`
+}
+
+// FailoverVendor sends through the first of an ordered list of vendors that
+// accepts the message, skipping vendors whose circuit breaker is open. A
+// message rejected for its own sake, e.g. an invalid number, isn't retried.
+type FailoverVendor struct {
+	links []failoverLink
+}
+
+// failoverMember is a vendor and its breaker, shared by every chain that
+// sends through it; see VendorPool.
+type failoverMember struct {
+	name    string
+	vendor  SmsVendor
+	breaker *breaker
+}
+
+// failoverLink is a member of one chain, with the template that chain sends
+// codes with, if not the configured one.
+type failoverLink struct {
+	*failoverMember
+	template string
+}
+
+func (f *FailoverVendor) SendCode(phoneNumber string, params TemplateParams) (*Receipt, error) {
+	return f.send(func(l failoverLink) (*Receipt, error) {
+		if l.template != "" {
+			return l.vendor.(templateSender).sendCode(phoneNumber, l.template, params)
+		}
+		return l.vendor.SendCode(phoneNumber, params)
+	})
+}
+
+func (f *FailoverVendor) SendSms(phoneNumber string, msg *Message) (*Receipt, error) {
+	return f.send(func(l failoverLink) (*Receipt, error) {
+		return l.vendor.SendSms(phoneNumber, msg)
+	})
+}
+
+func (f *FailoverVendor) send(send func(failoverLink) (*Receipt, error)) (*Receipt, error) {
+	now := time.Now()
+
+	var candidates []failoverLink
+	for _, l := range f.links {
+		if l.breaker.allow(now) {
+			candidates = append(candidates, l)
+		}
+	}
+
+	// With every breaker open, trying them all beats failing outright.
+	if len(candidates) == 0 {
+		candidates = f.links
+	}
+
+	var errs []error
+	for _, l := range candidates {
+		receipt, err := send(l)
+		if errors.Is(err, ErrUnsupportedMessage) {
+			errs = append(errs, fmt.Errorf("%s: %w", l.name, err))
+			continue
+		}
+		if err != nil && !IsRetryable(err) {
+			// The message itself was rejected, so other vendors would
+			// reject it too, and the vendor is healthy.
+			l.breaker.success()
+			return nil, fmt.Errorf("%s: %w", l.name, err)
+		}
+		if err != nil {
+			if l.breaker.failure(time.Now()) {
+				log.Printf("sms: circuit breaker opened for %s: %v", l.name, err)
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", l.name, err))
+			continue
+		}
+
+		l.breaker.success()
+		if receipt == nil {
+			receipt = &Receipt{}
+		}
+		if receipt.Vendor == "" {
+			receipt.Vendor = l.name
+		}
+		if len(errs) > 0 {
+			log.Printf("sms: failed over to %s after %d errors", l.name, len(errs))
+		}
+		return receipt, nil
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// breaker counts consecutive failures of a vendor. Once threshold is reached
+// it stays open for cooldown, then lets sends through again; the next
+// failure reopens it straight away.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+func (b *breaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures < b.threshold || !now.Before(b.openUntil)
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+}
+
+// failure records an error and reports whether it opened a closed breaker.
+func (b *breaker) failure(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures < b.threshold {
+		return false
+	}
+	b.openUntil = now.Add(b.cooldown)
+	return b.failures == b.threshold
+}
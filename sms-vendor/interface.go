@@ -1,6 +1,77 @@
 package sms
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
 type SmsVendor interface {
-	SendCode(phoneNumber, code string) error
-	SendCodeNProduct(phoneNumber, code, product string) error
+	// SendCode sends the verification template; params carries at least
+	// "code".
+	SendCode(phoneNumber string, params TemplateParams) (*Receipt, error)
+	SendSms(phoneNumber string, msg *Message) (*Receipt, error)
+}
+
+// TemplateParams are the variables of an SMS template, e.g. code and
+// product.
+type TemplateParams map[string]string
+
+// JSON serializes p for vendors that take template variables as a JSON
+// object.
+func (p TemplateParams) JSON() (string, error) {
+	if p == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(p)
+	return string(data), err
+}
+
+// Render replaces the {name} placeholders of text with the values of p.
+func (p TemplateParams) Render(text string) string {
+	var oldnew []string
+	for name, value := range p {
+		oldnew = append(oldnew, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(oldnew...).Replace(text)
+}
+
+// Message is a transactional SMS. Vendors with an entry in TemplateIds send
+// that template with Params; others send Text with its {name} placeholders
+// replaced from Params.
+type Message struct {
+	// TemplateIds holds template IDs by provider, e.g. VOLC.
+	TemplateIds map[string]string
+	Params      TemplateParams
+	Text        string
 }
+
+// ErrUnsupportedMessage means a vendor can't send a message as given, e.g.
+// text to a vendor that only sends templates. It says nothing about the
+// vendor's health.
+var ErrUnsupportedMessage = errors.New("message not supported by vendor")
+
+// render returns the text of msg for vendors without templates.
+func (msg *Message) render() (string, error) {
+	if msg.Text == "" {
+		return "", fmt.Errorf("%w: no text and no template", ErrUnsupportedMessage)
+	}
+
+	return msg.Params.Render(msg.Text), nil
+}
+
+// Receipt identifies an accepted message.
+type Receipt struct {
+	// Vendor is the provider name, e.g. VOLC.
+	Vendor    string
+	MessageID string
+}
+
+const (
+	ProviderVolc     = "VOLC"
+	ProviderBytePlus = "BYTEPLUS"
+	ProviderAliyun   = "ALIYUN"
+	ProviderTwilio   = "TWILIO"
+	ProviderSmpp     = "SMPP"
+)
@@ -0,0 +1,96 @@
+package sms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/more-than-code/messaging/phone"
+)
+
+// RoutingTable assigns vendor chains to destination countries; see
+// sms-routes.example.json.
+type RoutingTable struct {
+	Routes []Route `json:"routes"`
+	// Default serves countries without a route.
+	Default []VendorSpec `json:"default,omitempty"`
+}
+
+type Route struct {
+	// CountryCodes are country calling codes, e.g. 86.
+	CountryCodes []int        `json:"country_codes"`
+	Vendors      []VendorSpec `json:"vendors"`
+}
+
+// Router sends each message through the vendor chain of its destination
+// country.
+type Router struct {
+	routes   map[int]SmsVendor
+	fallback SmsVendor
+}
+
+// LoadRouter reads a RoutingTable from filePath and builds its chains from
+// pool. Countries without a route go to the table's default chain or, if it
+// has none, to fallback.
+func LoadRouter(filePath string, pool *VendorPool, fallback SmsVendor) (*Router, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var table RoutingTable
+	err = json.Unmarshal(data, &table)
+	if err != nil {
+		return nil, fmt.Errorf("parsing sms routes: %w", err)
+	}
+
+	r := &Router{routes: make(map[int]SmsVendor), fallback: fallback}
+
+	for i, route := range table.Routes {
+		chain, err := pool.Chain(route.Vendors)
+		if err != nil {
+			return nil, fmt.Errorf("sms route %d: %w", i, err)
+		}
+
+		for _, cc := range route.CountryCodes {
+			if _, ok := r.routes[cc]; ok {
+				return nil, fmt.Errorf("sms route %d: country code %d is already routed", i, cc)
+			}
+			r.routes[cc] = chain
+		}
+	}
+
+	if len(table.Default) > 0 {
+		r.fallback, err = pool.Chain(table.Default)
+		if err != nil {
+			return nil, fmt.Errorf("default sms route: %w", err)
+		}
+	}
+
+	if r.fallback == nil {
+		return nil, errors.New("sms routes need a default route or SMS_PROVIDER")
+	}
+
+	return r, nil
+}
+
+// Len returns the number of routed country codes.
+func (r *Router) Len() int {
+	return len(r.routes)
+}
+
+func (r *Router) route(phoneNumber string) SmsVendor {
+	if vendor, ok := r.routes[phone.CountryCode(phoneNumber)]; ok {
+		return vendor
+	}
+	return r.fallback
+}
+
+func (r *Router) SendCode(phoneNumber string, params TemplateParams) (*Receipt, error) {
+	return r.route(phoneNumber).SendCode(phoneNumber, params)
+}
+
+func (r *Router) SendSms(phoneNumber string, msg *Message) (*Receipt, error) {
+	return r.route(phoneNumber).SendSms(phoneNumber, msg)
+}
@@ -0,0 +1,55 @@
+package sms
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadRouterSharesVendors(t *testing.T) {
+	stub := &twilioStub{status: http.StatusCreated, body: `{"sid": "SM123"}`}
+	newTwilioTestVendor(t, TwilioModeMessages, stub)
+
+	routes := `{
+		"routes": [
+			{"country_codes": [44], "vendors": [{"provider": "TWILIO"}]},
+			{"country_codes": [86], "vendors": [{"provider": "TWILIO", "template": "Code: {code}"}]}
+		],
+		"default": [{"provider": "TWILIO"}]
+	}`
+	path := filepath.Join(t.TempDir(), "routes.json")
+	if err := os.WriteFile(path, []byte(routes), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := LoadRouter(path, NewVendorPool(FailoverConfig{BreakerThreshold: 1}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uk := r.route("+447700900123").(*FailoverVendor).links[0]
+	cn := r.route("+8613800138000").(*FailoverVendor).links[0]
+	us := r.route("+14155550100").(*FailoverVendor).links[0]
+
+	if uk.failoverMember != us.failoverMember || uk.failoverMember != cn.failoverMember {
+		t.Error("chains have separate TWILIO instances")
+	}
+
+	// Each chain still sends codes with its own template.
+	tests := []struct {
+		phoneNumber string
+		body        string
+	}{
+		{"+8613800138000", "Code: 1234"},
+		{"+447700900123", "Your Acme code is 1234"},
+	}
+	for _, tt := range tests {
+		if _, err := r.SendCode(tt.phoneNumber, TemplateParams{"code": "1234", "product": "Acme"}); err != nil {
+			t.Fatal(err)
+		}
+		if got := stub.form.Get("Body"); got != tt.body {
+			t.Errorf("%s: got body %q, want %q", tt.phoneNumber, got, tt.body)
+		}
+	}
+}
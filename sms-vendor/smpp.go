@@ -0,0 +1,147 @@
+package sms
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/more-than-code/messaging/sms-vendor/smpp"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// SmppVendor submits codes straight to a carrier or aggregator SMSC over
+// SMPP 3.4.
+type SmppVendor struct {
+	cfg    SmppConfig
+	client *smpp.Client
+}
+
+type SmppConfig struct {
+	Addr       string `envconfig:"SMPP_ADDR"`
+	SystemId   string `envconfig:"SMPP_SYSTEM_ID"`
+	Password   string `envconfig:"SMPP_PASSWORD"`
+	SystemType string `envconfig:"SMPP_SYSTEM_TYPE"`
+	// Source is the sender ID, a number or an alphanumeric name.
+	Source string `envconfig:"SMPP_SOURCE"`
+	// Message is the text sent. {code}, {product} and other template params
+	// are replaced.
+	Message         string        `envconfig:"SMPP_MESSAGE" default:"Your verification code is {code}"`
+	EnquireInterval time.Duration `envconfig:"SMPP_ENQUIRE_INTERVAL" default:"30s"`
+	ResponseTimeout time.Duration `envconfig:"SMPP_RESPONSE_TIMEOUT" default:"10s"`
+	ReconnectDelay  time.Duration `envconfig:"SMPP_RECONNECT_DELAY" default:"5s"`
+}
+
+// Type of number and numbering plan values for addresses.
+const (
+	tonInternational = 0x01
+	tonAlphanumeric  = 0x05
+	npiUnknown       = 0x00
+	npiE164          = 0x01
+)
+
+func NewSmppVendor() (*SmppVendor, error) {
+	var cfg SmppConfig
+	err := envconfig.Process("", &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Addr == "" || cfg.SystemId == "" {
+		return nil, errors.New("smpp address and system id are required")
+	}
+
+	client := smpp.Dial(smpp.Config{
+		Addr:            cfg.Addr,
+		SystemId:        cfg.SystemId,
+		Password:        cfg.Password,
+		SystemType:      cfg.SystemType,
+		EnquireInterval: cfg.EnquireInterval,
+		ResponseTimeout: cfg.ResponseTimeout,
+		ReconnectDelay:  cfg.ReconnectDelay,
+	}, func(r smpp.DeliveryReceipt) {
+		log.Printf("smpp: message %s finished with %s (err %s)", r.MessageId, r.Stat, r.Err)
+	})
+
+	return &SmppVendor{cfg: cfg, client: client}, nil
+}
+
+func (v *SmppVendor) SendCode(phoneNumber string, params TemplateParams) (*Receipt, error) {
+	return v.sendCode(phoneNumber, "", params)
+}
+
+// sendCode takes template as the message text.
+func (v *SmppVendor) sendCode(phoneNumber, template string, params TemplateParams) (*Receipt, error) {
+	if template == "" {
+		template = v.cfg.Message
+	}
+
+	return v.submit(phoneNumber, params.Render(template))
+}
+
+// SendSms sends text; SMPP has no templates.
+func (v *SmppVendor) SendSms(phoneNumber string, msg *Message) (*Receipt, error) {
+	text, err := msg.render()
+	if err != nil {
+		return nil, err
+	}
+	return v.submit(phoneNumber, text)
+}
+
+func (v *SmppVendor) submit(phoneNumber, text string) (*Receipt, error) {
+	msg := smpp.ShortMessage{
+		Source:    v.cfg.Source,
+		SourceTon: tonAlphanumeric,
+		SourceNpi: npiUnknown,
+		Dest:      strings.TrimPrefix(phoneNumber, "+"),
+		DestTon:   tonInternational,
+		DestNpi:   npiE164,
+		Text:      text,
+	}
+
+	if isDigits(strings.TrimPrefix(v.cfg.Source, "+")) {
+		msg.Source = strings.TrimPrefix(v.cfg.Source, "+")
+		msg.SourceTon = tonInternational
+		msg.SourceNpi = npiE164
+	}
+
+	ids, err := v.client.Submit(msg)
+	if err != nil {
+		log.Printf("smpp: submit to %s failed: %v", phoneNumber, err)
+		return nil, smppSendError(err, ids)
+	}
+
+	return &Receipt{Vendor: ProviderSmpp, MessageID: ids[0]}, nil
+}
+
+// smppSendError wraps a failed submit. ids holds the parts submitted before
+// it failed. Command statuses are retryable when temporary; anything else,
+// like a lost session, blames the SMSC.
+func smppSendError(err error, ids []string) *SendError {
+	sendErr := &SendError{Vendor: ProviderSmpp, Retryable: true, Err: err}
+	if len(ids) > 0 {
+		sendErr.MessageID = ids[0]
+	}
+
+	var statusErr *smpp.StatusError
+	if errors.As(err, &statusErr) {
+		sendErr.Code = fmt.Sprintf("0x%08X", statusErr.Status)
+		sendErr.Retryable = statusErr.Temporary()
+	}
+
+	return sendErr
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
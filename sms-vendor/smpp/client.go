@@ -0,0 +1,413 @@
+package smpp
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrNotBound = errors.New("smpp: not bound")
+	ErrClosed   = errors.New("smpp: client closed")
+)
+
+type Config struct {
+	Addr       string
+	SystemId   string
+	Password   string
+	SystemType string
+	// EnquireInterval is how often an idle session is probed.
+	EnquireInterval time.Duration
+	// ResponseTimeout bounds the wait for each response.
+	ResponseTimeout time.Duration
+	// ReconnectDelay is the pause before rebinding a lost session.
+	ReconnectDelay time.Duration
+}
+
+// ShortMessage is a text message to submit. Addresses are sent as given with
+// their type of number (TON) and numbering plan indicator (NPI).
+type ShortMessage struct {
+	Source    string
+	SourceTon byte
+	SourceNpi byte
+	Dest      string
+	DestTon   byte
+	DestNpi   byte
+	Text      string
+}
+
+// DeliveryReceipt reports the final state of a submitted message.
+type DeliveryReceipt struct {
+	MessageId string
+	// Stat is e.g. DELIVRD, UNDELIV, EXPIRED or REJECTD.
+	Stat string
+	Err  string
+}
+
+// Client keeps a transceiver bind to one SMSC, rebinding whenever the
+// session is lost, until Close.
+type Client struct {
+	cfg       Config
+	onReceipt func(DeliveryReceipt)
+
+	mu      sync.Mutex
+	session *session
+	// bound is closed once session is set.
+	bound chan struct{}
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// Dial starts binding to the SMSC in the background. onReceipt, if set, is
+// called for every delivery receipt.
+func Dial(cfg Config, onReceipt func(DeliveryReceipt)) *Client {
+	c := &Client{cfg: cfg, onReceipt: onReceipt, bound: make(chan struct{}), closed: make(chan struct{})}
+	go c.run()
+	return c
+}
+
+func (c *Client) run() {
+	for {
+		s, err := c.bind()
+		if err != nil {
+			log.Printf("smpp: bind to %s failed: %v", c.cfg.Addr, err)
+		} else {
+			log.Printf("smpp: bound to %s as %s", c.cfg.Addr, c.cfg.SystemId)
+			c.setSession(s)
+			go c.keepalive(s)
+
+			select {
+			case <-s.done:
+				log.Printf("smpp: session to %s lost: %v", c.cfg.Addr, s.err)
+			case <-c.closed:
+			}
+			c.setSession(nil)
+		}
+
+		select {
+		case <-c.closed:
+			return
+		case <-time.After(c.cfg.ReconnectDelay):
+		}
+	}
+}
+
+func (c *Client) bind() (*session, error) {
+	conn, err := net.DialTimeout("tcp", c.cfg.Addr, c.cfg.ResponseTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	s := newSession(conn, c.cfg.ResponseTimeout)
+	go s.readLoop(c.handle)
+
+	var body bodyWriter
+	body.cString(c.cfg.SystemId)
+	body.cString(c.cfg.Password)
+	body.cString(c.cfg.SystemType)
+	body.octet(interfaceVersion)
+	body.octet(0) // addr_ton
+	body.octet(0) // addr_npi
+	body.cString("")
+
+	_, err = s.call(BindTransceiver, body.Bytes())
+	if err != nil {
+		s.close(err)
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (c *Client) keepalive(s *session) {
+	ticker := time.NewTicker(c.cfg.EnquireInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if _, err := s.call(EnquireLink, nil); err != nil {
+				s.close(fmt.Errorf("enquire_link: %w", err))
+				return
+			}
+		}
+	}
+}
+
+// handle answers the requests the SMSC sends us.
+func (c *Client) handle(s *session, p *Pdu) {
+	switch p.CommandId {
+	case EnquireLink:
+		s.reply(p, EnquireLinkResp, StatusOk, nil)
+	case DeliverSm:
+		s.reply(p, DeliverSmResp, StatusOk, []byte{0})
+		c.deliver(p)
+	case Unbind:
+		s.reply(p, UnbindResp, StatusOk, nil)
+		s.close(errors.New("unbound by smsc"))
+	default:
+		s.reply(p, GenericNack, StatusInvalidCmd, nil)
+	}
+}
+
+func (c *Client) deliver(p *Pdu) {
+	r := &bodyReader{b: p.Body}
+	r.cString() // service_type
+	r.octets(2) // source_addr_ton, source_addr_npi
+	r.cString() // source_addr
+	r.octets(2) // dest_addr_ton, dest_addr_npi
+	r.cString() // destination_addr
+	esmClass := r.octet()
+	r.octets(2) // protocol_id, priority_flag
+	r.cString() // schedule_delivery_time
+	r.cString() // validity_period
+	r.octets(4) // registered_delivery, replace_if_present_flag, data_coding, sm_default_msg_id
+	text := r.octets(int(r.octet()))
+	if r.err != nil {
+		log.Printf("smpp: malformed deliver_sm: %v", r.err)
+		return
+	}
+
+	// Only delivery receipts are expected; mobile originated messages are
+	// dropped.
+	if esmClass&0x3C != 0x04 {
+		return
+	}
+
+	receipt := parseReceipt(string(text))
+	if id, ok := r.tlvs()[tagReceiptedMessageId]; ok {
+		receipt.MessageId = string(trimNul(id))
+	}
+
+	if c.onReceipt != nil {
+		c.onReceipt(receipt)
+	}
+}
+
+func trimNul(b []byte) []byte {
+	for len(b) > 0 && b[len(b)-1] == 0 {
+		b = b[:len(b)-1]
+	}
+	return b
+}
+
+func (c *Client) setSession(s *session) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.session = s
+	if s != nil {
+		close(c.bound)
+	} else {
+		c.bound = make(chan struct{})
+	}
+}
+
+func (c *Client) current() *session {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.session
+}
+
+// await returns the bound session, waiting up to the response timeout for a
+// bind or rebind in progress.
+func (c *Client) await() (*session, error) {
+	c.mu.Lock()
+	s, bound := c.session, c.bound
+	c.mu.Unlock()
+
+	if s != nil {
+		return s, nil
+	}
+
+	timer := time.NewTimer(c.cfg.ResponseTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-bound:
+		return c.await()
+	case <-c.closed:
+		return nil, ErrClosed
+	case <-timer.C:
+		return nil, ErrNotBound
+	}
+}
+
+// Submit sends msg, split into concatenated parts when it doesn't fit one
+// SMS, and returns the SMSC message ID of each part.
+func (c *Client) Submit(msg ShortMessage) ([]string, error) {
+	s, err := c.await()
+	if err != nil {
+		return nil, err
+	}
+
+	coding, parts := Encode(msg.Text)
+
+	var ref [1]byte
+	if len(parts) > 1 {
+		_, _ = rand.Read(ref[:])
+	}
+
+	var ids []string
+	for i, part := range parts {
+		var esmClass byte
+		if len(parts) > 1 {
+			esmClass = esmClassUdhi
+			part = append(concatHeader(ref[0], len(parts), i+1), part...)
+		}
+
+		var body bodyWriter
+		body.cString("") // service_type
+		body.octet(msg.SourceTon)
+		body.octet(msg.SourceNpi)
+		body.cString(msg.Source)
+		body.octet(msg.DestTon)
+		body.octet(msg.DestNpi)
+		body.cString(msg.Dest)
+		body.octet(esmClass)
+		body.octet(0)    // protocol_id
+		body.octet(0)    // priority_flag
+		body.cString("") // schedule_delivery_time
+		body.cString("") // validity_period
+		body.octet(1)    // registered_delivery: receipt on final state
+		body.octet(0)    // replace_if_present_flag
+		body.octet(coding)
+		body.octet(0) // sm_default_msg_id
+		body.octet(byte(len(part)))
+		body.Write(part)
+
+		resp, err := s.call(SubmitSm, body.Bytes())
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, (&bodyReader{b: resp.Body}).cString())
+	}
+
+	return ids, nil
+}
+
+// Close unbinds and stops reconnecting.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		// Take the session first; once closed is, run drops it.
+		s := c.current()
+		close(c.closed)
+		if s != nil {
+			_, _ = s.call(Unbind, nil)
+			s.close(ErrClosed)
+		}
+	})
+	return nil
+}
+
+// session is one bound connection. Requests are matched to responses by
+// sequence number.
+type session struct {
+	conn    net.Conn
+	timeout time.Duration
+	seq     atomic.Uint32
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[uint32]chan *Pdu
+
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error
+}
+
+func newSession(conn net.Conn, timeout time.Duration) *session {
+	return &session{conn: conn, timeout: timeout, pending: make(map[uint32]chan *Pdu), done: make(chan struct{})}
+}
+
+func (s *session) readLoop(handle func(*session, *Pdu)) {
+	for {
+		p, err := readPdu(s.conn)
+		if err != nil {
+			s.close(err)
+			return
+		}
+
+		if !p.isResponse() {
+			handle(s, p)
+			continue
+		}
+
+		s.mu.Lock()
+		ch, ok := s.pending[p.Seq]
+		delete(s.pending, p.Seq)
+		s.mu.Unlock()
+
+		if ok {
+			ch <- p
+		}
+	}
+}
+
+func (s *session) write(p *Pdu) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_ = s.conn.SetWriteDeadline(time.Now().Add(s.timeout))
+	return writePdu(s.conn, p)
+}
+
+// call sends a request and waits for its response, failing on a non-zero
+// command status.
+func (s *session) call(commandId uint32, body []byte) (*Pdu, error) {
+	seq := s.seq.Add(1)
+	ch := make(chan *Pdu, 1)
+
+	s.mu.Lock()
+	s.pending[seq] = ch
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, seq)
+		s.mu.Unlock()
+	}()
+
+	err := s.write(&Pdu{CommandId: commandId, Seq: seq, Body: body})
+	if err != nil {
+		s.close(err)
+		return nil, err
+	}
+
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+
+	select {
+	case p := <-ch:
+		if p.Status != StatusOk {
+			return nil, &StatusError{CommandId: commandId, Status: p.Status}
+		}
+		return p, nil
+	case <-s.done:
+		return nil, fmt.Errorf("smpp: session closed: %w", s.err)
+	case <-timer.C:
+		return nil, fmt.Errorf("smpp: no response to command 0x%08X", commandId)
+	}
+}
+
+func (s *session) reply(req *Pdu, commandId, status uint32, body []byte) {
+	err := s.write(&Pdu{CommandId: commandId, Status: status, Seq: req.Seq, Body: body})
+	if err != nil {
+		s.close(err)
+	}
+}
+
+func (s *session) close(err error) {
+	s.closeOnce.Do(func() {
+		s.err = err
+		close(s.done)
+		_ = s.conn.Close()
+	})
+}
@@ -0,0 +1,397 @@
+package smpp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testSmsc is a loopback SMSC stand-in. It answers binds, submits, enquire
+// links and unbinds, and passes every pdu it reads to received.
+type testSmsc struct {
+	t        *testing.T
+	ln       net.Listener
+	received chan *Pdu
+
+	mu           sync.Mutex
+	conn         net.Conn
+	submitStatus uint32
+	submits      int
+	// dropAfterBind closes the next connection right after its bind.
+	dropAfterBind bool
+}
+
+func newTestSmsc(t *testing.T) *testSmsc {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &testSmsc{t: t, ln: ln, received: make(chan *Pdu, 64)}
+	t.Cleanup(func() {
+		ln.Close()
+		s.mu.Lock()
+		if s.conn != nil {
+			s.conn.Close()
+		}
+		s.mu.Unlock()
+	})
+
+	go s.accept()
+	return s
+}
+
+func (s *testSmsc) accept() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conn = conn
+		s.mu.Unlock()
+		go s.serve(conn)
+	}
+}
+
+func (s *testSmsc) serve(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		p, err := readPdu(conn)
+		if err != nil {
+			return
+		}
+		s.received <- p
+
+		switch p.CommandId {
+		case BindTransceiver:
+			var body bodyWriter
+			body.cString("TESTSMSC")
+			s.write(conn, &Pdu{CommandId: BindTransceiverResp, Seq: p.Seq, Body: body.Bytes()})
+
+			s.mu.Lock()
+			drop := s.dropAfterBind
+			s.dropAfterBind = false
+			s.mu.Unlock()
+			if drop {
+				return
+			}
+		case SubmitSm:
+			s.mu.Lock()
+			s.submits++
+			id, status := fmt.Sprintf("msg-%d", s.submits), s.submitStatus
+			s.mu.Unlock()
+
+			var body bodyWriter
+			if status == StatusOk {
+				body.cString(id)
+			}
+			s.write(conn, &Pdu{CommandId: SubmitSmResp, Status: status, Seq: p.Seq, Body: body.Bytes()})
+		case EnquireLink:
+			s.write(conn, &Pdu{CommandId: EnquireLinkResp, Seq: p.Seq})
+		case Unbind:
+			s.write(conn, &Pdu{CommandId: UnbindResp, Seq: p.Seq})
+			return
+		}
+	}
+}
+
+func (s *testSmsc) write(conn net.Conn, p *Pdu) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := writePdu(conn, p); err != nil {
+		s.t.Logf("smsc write: %v", err)
+	}
+}
+
+// send writes a request to the current connection.
+func (s *testSmsc) send(p *Pdu) {
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+	s.write(conn, p)
+}
+
+// next returns the next pdu read with commandId, skipping others.
+func (s *testSmsc) next(commandId uint32) *Pdu {
+	s.t.Helper()
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case p := <-s.received:
+			if p.CommandId == commandId {
+				return p
+			}
+		case <-timeout:
+			s.t.Fatalf("no pdu 0x%08X received", commandId)
+			return nil
+		}
+	}
+}
+
+func dialTestClient(t *testing.T, s *testSmsc, cfg Config, onReceipt func(DeliveryReceipt)) *Client {
+	t.Helper()
+
+	cfg.Addr = s.ln.Addr().String()
+	if cfg.SystemId == "" {
+		cfg.SystemId = "esme"
+	}
+	if cfg.EnquireInterval == 0 {
+		cfg.EnquireInterval = time.Hour
+	}
+	cfg.ResponseTimeout = 2 * time.Second
+	cfg.ReconnectDelay = 10 * time.Millisecond
+
+	c := Dial(cfg, onReceipt)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func testMessage(text string) ShortMessage {
+	return ShortMessage{Source: "Acme", SourceTon: 0x05, Dest: "14155550100", DestTon: 0x01, DestNpi: 0x01, Text: text}
+}
+
+func TestClientBind(t *testing.T) {
+	s := newTestSmsc(t)
+	dialTestClient(t, s, Config{SystemId: "esme", Password: "secret", SystemType: "OTP"}, nil)
+
+	r := &bodyReader{b: s.next(BindTransceiver).Body}
+	systemId, password, systemType, version := r.cString(), r.cString(), r.cString(), r.octet()
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if systemId != "esme" || password != "secret" || systemType != "OTP" || version != interfaceVersion {
+		t.Errorf("got bind %q %q %q 0x%02X", systemId, password, systemType, version)
+	}
+}
+
+func TestClientSubmit(t *testing.T) {
+	s := newTestSmsc(t)
+	c := dialTestClient(t, s, Config{}, nil)
+
+	ids, err := c.Submit(testMessage("Your code is 1234"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != "msg-1" {
+		t.Errorf("got ids %v", ids)
+	}
+
+	r := &bodyReader{b: s.next(SubmitSm).Body}
+	r.cString() // service_type
+	sourceTon, _ := r.octet(), r.octet()
+	source := r.cString()
+	destTon, destNpi := r.octet(), r.octet()
+	dest := r.cString()
+	esmClass := r.octet()
+	r.octets(2) // protocol_id, priority_flag
+	r.cString() // schedule_delivery_time
+	r.cString() // validity_period
+	registeredDelivery := r.octet()
+	r.octet() // replace_if_present_flag
+	coding := r.octet()
+	r.octet() // sm_default_msg_id
+	text := r.octets(int(r.octet()))
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+
+	if source != "Acme" || sourceTon != 0x05 || dest != "14155550100" || destTon != 0x01 || destNpi != 0x01 {
+		t.Errorf("got addresses %q (ton %d) -> %q (ton %d, npi %d)", source, sourceTon, dest, destTon, destNpi)
+	}
+	if esmClass != 0 || registeredDelivery != 1 || coding != CodingDefault {
+		t.Errorf("got esm_class 0x%02X, registered_delivery %d, data_coding 0x%02X", esmClass, registeredDelivery, coding)
+	}
+	if string(text) != "Your code is 1234" {
+		t.Errorf("got text %q", text)
+	}
+}
+
+func TestClientSubmitConcatenated(t *testing.T) {
+	s := newTestSmsc(t)
+	c := dialTestClient(t, s, Config{}, nil)
+
+	text := strings.Repeat("a", 200)
+	ids, err := c.Submit(testMessage(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != "msg-1" || ids[1] != "msg-2" {
+		t.Errorf("got ids %v", ids)
+	}
+
+	var ref byte
+	var joined []byte
+	for seq := 1; seq <= 2; seq++ {
+		r := &bodyReader{b: s.next(SubmitSm).Body}
+		r.cString()
+		r.octets(2)
+		r.cString()
+		r.octets(2)
+		r.cString()
+		esmClass := r.octet()
+		r.octets(2)
+		r.cString()
+		r.cString()
+		r.octets(4)
+		sm := r.octets(int(r.octet()))
+		if r.err != nil {
+			t.Fatal(r.err)
+		}
+
+		if esmClass != esmClassUdhi {
+			t.Errorf("part %d: got esm_class 0x%02X", seq, esmClass)
+		}
+		if seq == 1 {
+			ref = sm[3]
+		}
+		if !bytes.Equal(sm[:6], concatHeader(ref, 2, seq)) {
+			t.Errorf("part %d: got header % X", seq, sm[:6])
+		}
+		joined = append(joined, sm[6:]...)
+	}
+
+	if string(joined) != text {
+		t.Errorf("parts join to %q", joined)
+	}
+}
+
+func TestClientSubmitStatusError(t *testing.T) {
+	s := newTestSmsc(t)
+	s.submitStatus = StatusThrottled
+	c := dialTestClient(t, s, Config{}, nil)
+
+	_, err := c.Submit(testMessage("Your code is 1234"))
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("got %v, want a StatusError", err)
+	}
+	if statusErr.CommandId != SubmitSm || statusErr.Status != StatusThrottled || !statusErr.Temporary() {
+		t.Errorf("got %+v", statusErr)
+	}
+}
+
+func TestClientEnquireLink(t *testing.T) {
+	s := newTestSmsc(t)
+	dialTestClient(t, s, Config{EnquireInterval: 20 * time.Millisecond}, nil)
+
+	s.next(EnquireLink)
+	s.next(EnquireLink)
+}
+
+func TestClientAnswersSmsc(t *testing.T) {
+	s := newTestSmsc(t)
+	dialTestClient(t, s, Config{}, nil)
+	s.next(BindTransceiver)
+
+	s.send(&Pdu{CommandId: EnquireLink, Seq: 7})
+	if p := s.next(EnquireLinkResp); p.Seq != 7 || p.Status != StatusOk {
+		t.Errorf("got %+v", p)
+	}
+
+	s.send(&Pdu{CommandId: 0x00000103, Seq: 8}) // data_sm, unsupported
+	if p := s.next(GenericNack); p.Seq != 8 || p.Status != StatusInvalidCmd {
+		t.Errorf("got %+v", p)
+	}
+}
+
+func TestClientReconnect(t *testing.T) {
+	s := newTestSmsc(t)
+	s.dropAfterBind = true
+	c := dialTestClient(t, s, Config{}, nil)
+
+	s.next(BindTransceiver)
+	s.next(BindTransceiver)
+
+	ids, err := c.Submit(testMessage("Your code is 1234"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 {
+		t.Errorf("got ids %v", ids)
+	}
+}
+
+func TestClientSubmitAfterClose(t *testing.T) {
+	s := newTestSmsc(t)
+	c := dialTestClient(t, s, Config{}, nil)
+	if _, err := c.Submit(testMessage("Your code is 1234")); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Close()
+	s.next(Unbind)
+
+	if _, err := c.Submit(testMessage("Your code is 1234")); err == nil {
+		t.Error("submit after close succeeded")
+	}
+}
+
+func TestClientDeliveryReceipt(t *testing.T) {
+	receipts := make(chan DeliveryReceipt, 1)
+
+	s := newTestSmsc(t)
+	dialTestClient(t, s, Config{}, func(r DeliveryReceipt) { receipts <- r })
+	s.next(BindTransceiver)
+
+	deliverSm := func(esmClass byte, text string, tlvs ...byte) []byte {
+		var body bodyWriter
+		body.cString("")
+		body.octet(1)
+		body.octet(1)
+		body.cString("14155550100")
+		body.octet(5)
+		body.octet(0)
+		body.cString("Acme")
+		body.octet(esmClass)
+		body.octet(0)
+		body.octet(0)
+		body.cString("")
+		body.cString("")
+		body.octet(0)
+		body.octet(0)
+		body.octet(0)
+		body.octet(0)
+		body.octet(byte(len(text)))
+		body.WriteString(text)
+		body.Write(tlvs)
+		return body.Bytes()
+	}
+
+	// Mobile originated messages are acknowledged but not reported.
+	s.send(&Pdu{CommandId: DeliverSm, Seq: 1, Body: deliverSm(0x00, "hello")})
+	if p := s.next(DeliverSmResp); p.Seq != 1 {
+		t.Errorf("got %+v", p)
+	}
+
+	tlv := []byte{0x00, 0x1E, 0x00, 0x07, 'm', 's', 'g', '-', '4', '2', 0}
+	s.send(&Pdu{CommandId: DeliverSm, Seq: 2, Body: deliverSm(0x04, "id:ignored sub:001 dlvrd:001 stat:DELIVRD err:000", tlv...)})
+	if p := s.next(DeliverSmResp); p.Seq != 2 || p.Status != StatusOk {
+		t.Errorf("got %+v", p)
+	}
+
+	select {
+	case r := <-receipts:
+		if r != (DeliveryReceipt{MessageId: "msg-42", Stat: "DELIVRD", Err: "000"}) {
+			t.Errorf("got receipt %+v", r)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no receipt reported")
+	}
+
+	select {
+	case r := <-receipts:
+		t.Errorf("got extra receipt %+v", r)
+	default:
+	}
+}
@@ -0,0 +1,136 @@
+package smpp
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// Data codings for submit_sm.
+const (
+	CodingDefault byte = 0x00
+	CodingUcs2    byte = 0x08
+)
+
+const (
+	gsm7Escape = 0x1B
+
+	gsm7SingleLen = 160
+	gsm7PartLen   = 153
+	ucs2SingleLen = 70
+	ucs2PartLen   = 67
+
+	// esmClassUdhi marks a short message that starts with a user data header.
+	esmClassUdhi = 0x40
+)
+
+// gsm7Basic is the GSM 03.38 default alphabet indexed by septet value.
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞ\x1bÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extension holds the characters sent as an escape plus a septet.
+var gsm7Extension = map[rune]byte{
+	'\f': 0x0A, '^': 0x14, '{': 0x28, '}': 0x29, '\\': 0x2F,
+	'[': 0x3C, '~': 0x3D, ']': 0x3E, '|': 0x40, '€': 0x65,
+}
+
+var gsm7Index = func() map[rune]byte {
+	index := make(map[rune]byte, 128)
+	i := 0
+	for _, r := range gsm7Basic {
+		if r != gsm7Escape {
+			index[r] = byte(i)
+		}
+		i++
+	}
+	return index
+}()
+
+// encodeGsm7 returns text as unpacked septets, or false if it has characters
+// outside the GSM 7-bit alphabet.
+func encodeGsm7(text string) ([]byte, bool) {
+	septets := make([]byte, 0, len(text))
+	for _, r := range text {
+		if b, ok := gsm7Index[r]; ok {
+			septets = append(septets, b)
+		} else if b, ok := gsm7Extension[r]; ok {
+			septets = append(septets, gsm7Escape, b)
+		} else {
+			return nil, false
+		}
+	}
+	return septets, true
+}
+
+// Encode picks GSM 7-bit when text allows it and UCS-2 otherwise, and splits
+// the result into short messages that fit one SMS each, allowing room for a
+// concatenation header when more than one is needed.
+func Encode(text string) (byte, [][]byte) {
+	if septets, ok := encodeGsm7(text); ok {
+		if len(septets) <= gsm7SingleLen {
+			return CodingDefault, [][]byte{septets}
+		}
+
+		var parts [][]byte
+		for len(septets) > 0 {
+			n := min(gsm7PartLen, len(septets))
+			// Keep an escape together with the septet it modifies.
+			if n < len(septets) && septets[n-1] == gsm7Escape {
+				n--
+			}
+			parts = append(parts, septets[:n])
+			septets = septets[n:]
+		}
+		return CodingDefault, parts
+	}
+
+	units := utf16.Encode([]rune(text))
+	if len(units) <= ucs2SingleLen {
+		return CodingUcs2, [][]byte{ucs2Bytes(units)}
+	}
+
+	var parts [][]byte
+	for len(units) > 0 {
+		n := min(ucs2PartLen, len(units))
+		// Keep surrogate pairs in one part.
+		if n < len(units) && utf16.IsSurrogate(rune(units[n-1])) && units[n-1] < 0xDC00 {
+			n--
+		}
+		parts = append(parts, ucs2Bytes(units[:n]))
+		units = units[n:]
+	}
+	return CodingUcs2, parts
+}
+
+func ucs2Bytes(units []uint16) []byte {
+	b := make([]byte, 0, len(units)*2)
+	for _, u := range units {
+		b = append(b, byte(u>>8), byte(u))
+	}
+	return b
+}
+
+// concatHeader is the 8-bit reference concatenation user data header.
+func concatHeader(ref byte, total, seq int) []byte {
+	return []byte{0x05, 0x00, 0x03, ref, byte(total), byte(seq)}
+}
+
+// parseReceipt reads the id, stat and err fields of a delivery receipt in
+// the SMPP 3.4 appendix B format.
+func parseReceipt(text string) DeliveryReceipt {
+	var receipt DeliveryReceipt
+	for _, field := range strings.Fields(text) {
+		key, value, ok := strings.Cut(field, ":")
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "id":
+			receipt.MessageId = value
+		case "stat":
+			receipt.Stat = value
+		case "err":
+			receipt.Err = value
+		}
+	}
+	return receipt
+}
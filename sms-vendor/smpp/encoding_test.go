@@ -0,0 +1,97 @@
+package smpp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		coding   byte
+		partLens []int
+	}{
+		{"ascii", "Your code is 1234", CodingDefault, []int{17}},
+		{"outside gsm7", "Ünïcödé? no: Ø£$@", CodingUcs2, []int{34}},
+		{"gsm7 basic accents", "äöñüàÄÖÑÜ£¥", CodingDefault, []int{11}},
+		{"escaped", "{€}", CodingDefault, []int{6}},
+		{"gsm7 single limit", strings.Repeat("a", 160), CodingDefault, []int{160}},
+		{"gsm7 concatenated", strings.Repeat("a", 161), CodingDefault, []int{153, 8}},
+		{"gsm7 escape at boundary", strings.Repeat("a", 152) + "€" + strings.Repeat("b", 10), CodingDefault, []int{152, 12}},
+		{"ucs2", "验证码 1234", CodingUcs2, []int{16}},
+		{"ucs2 single limit", strings.Repeat("验", 70), CodingUcs2, []int{140}},
+		{"ucs2 concatenated", strings.Repeat("验", 71), CodingUcs2, []int{134, 8}},
+		{"surrogate at boundary", strings.Repeat("验", 66) + "😀" + strings.Repeat("验", 10), CodingUcs2, []int{132, 24}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coding, parts := Encode(tt.text)
+			if coding != tt.coding {
+				t.Errorf("got coding 0x%02X, want 0x%02X", coding, tt.coding)
+			}
+
+			var lens []int
+			for _, part := range parts {
+				lens = append(lens, len(part))
+			}
+			if len(lens) != len(tt.partLens) {
+				t.Fatalf("got part lengths %v, want %v", lens, tt.partLens)
+			}
+			for i := range lens {
+				if lens[i] != tt.partLens[i] {
+					t.Fatalf("got part lengths %v, want %v", lens, tt.partLens)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeGsm7Septets(t *testing.T) {
+	_, parts := Encode("@A{€\n")
+	want := []byte{0x00, 0x41, gsm7Escape, 0x28, gsm7Escape, 0x65, 0x0A}
+	if !bytes.Equal(parts[0], want) {
+		t.Errorf("got % X, want % X", parts[0], want)
+	}
+}
+
+func TestEncodeUcs2Bytes(t *testing.T) {
+	_, parts := Encode("验😀")
+	want := []byte{0x9A, 0x8C, 0xD8, 0x3D, 0xDE, 0x00}
+	if !bytes.Equal(parts[0], want) {
+		t.Errorf("got % X, want % X", parts[0], want)
+	}
+}
+
+func TestConcatHeader(t *testing.T) {
+	got := concatHeader(0x7F, 3, 2)
+	want := []byte{0x05, 0x00, 0x03, 0x7F, 0x03, 0x02}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got % X, want % X", got, want)
+	}
+}
+
+func TestParseReceipt(t *testing.T) {
+	tests := []struct {
+		text string
+		want DeliveryReceipt
+	}{
+		{
+			"id:IIIIIIIIII sub:001 dlvrd:001 submit date:2410181200 done date:2410181201 stat:DELIVRD err:000 text:Your code",
+			DeliveryReceipt{MessageId: "IIIIIIIIII", Stat: "DELIVRD", Err: "000"},
+		},
+		{
+			"ID:abc STAT:UNDELIV ERR:012",
+			DeliveryReceipt{MessageId: "abc", Stat: "UNDELIV", Err: "012"},
+		},
+		{"not a receipt", DeliveryReceipt{}},
+	}
+
+	for _, tt := range tests {
+		if got := parseReceipt(tt.text); got != tt.want {
+			t.Errorf("parseReceipt(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
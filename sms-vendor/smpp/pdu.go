@@ -0,0 +1,187 @@
+// Package smpp is a minimal SMPP 3.4 client for submitting text messages to
+// an SMSC over a transceiver bind.
+package smpp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Command IDs used by the client.
+const (
+	GenericNack         uint32 = 0x80000000
+	BindTransceiver     uint32 = 0x00000009
+	BindTransceiverResp uint32 = 0x80000009
+	SubmitSm            uint32 = 0x00000004
+	SubmitSmResp        uint32 = 0x80000004
+	DeliverSm           uint32 = 0x00000005
+	DeliverSmResp       uint32 = 0x80000005
+	Unbind              uint32 = 0x00000006
+	UnbindResp          uint32 = 0x80000006
+	EnquireLink         uint32 = 0x00000015
+	EnquireLinkResp     uint32 = 0x80000015
+)
+
+// Command statuses the client inspects.
+const (
+	StatusOk          uint32 = 0x00000000
+	StatusInvalidCmd  uint32 = 0x00000003
+	StatusMsgQueueFul uint32 = 0x00000014
+	StatusSysErr      uint32 = 0x00000008
+	StatusThrottled   uint32 = 0x00000058
+)
+
+const (
+	headerLen = 16
+	// maxPduLen bounds what we accept from the SMSC.
+	maxPduLen = 64 * 1024
+
+	interfaceVersion = 0x34
+
+	tagReceiptedMessageId = 0x001E
+)
+
+type Pdu struct {
+	CommandId uint32
+	Status    uint32
+	Seq       uint32
+	Body      []byte
+}
+
+func (p *Pdu) isResponse() bool {
+	return p.CommandId&GenericNack != 0
+}
+
+func readPdu(r io.Reader) (*Pdu, error) {
+	var header [headerLen]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header[0:4])
+	if length < headerLen || length > maxPduLen {
+		return nil, fmt.Errorf("smpp: invalid pdu length %d", length)
+	}
+
+	p := &Pdu{
+		CommandId: binary.BigEndian.Uint32(header[4:8]),
+		Status:    binary.BigEndian.Uint32(header[8:12]),
+		Seq:       binary.BigEndian.Uint32(header[12:16]),
+		Body:      make([]byte, length-headerLen),
+	}
+	if _, err := io.ReadFull(r, p.Body); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func writePdu(w io.Writer, p *Pdu) error {
+	buf := make([]byte, headerLen, headerLen+len(p.Body))
+	binary.BigEndian.PutUint32(buf[0:4], uint32(headerLen+len(p.Body)))
+	binary.BigEndian.PutUint32(buf[4:8], p.CommandId)
+	binary.BigEndian.PutUint32(buf[8:12], p.Status)
+	binary.BigEndian.PutUint32(buf[12:16], p.Seq)
+	buf = append(buf, p.Body...)
+
+	_, err := w.Write(buf)
+	return err
+}
+
+// bodyWriter appends the mandatory parameters of a pdu body.
+type bodyWriter struct {
+	bytes.Buffer
+}
+
+func (w *bodyWriter) cString(s string) {
+	w.WriteString(s)
+	w.WriteByte(0)
+}
+
+func (w *bodyWriter) octet(b byte) {
+	w.WriteByte(b)
+}
+
+var errShortBody = errors.New("smpp: truncated pdu body")
+
+// bodyReader consumes the parameters of a pdu body in order. After the body
+// runs short every read returns a zero value and err is set.
+type bodyReader struct {
+	b   []byte
+	off int
+	err error
+}
+
+func (r *bodyReader) cString() string {
+	if r.err != nil {
+		return ""
+	}
+	i := bytes.IndexByte(r.b[r.off:], 0)
+	if i < 0 {
+		r.err = errShortBody
+		return ""
+	}
+	s := string(r.b[r.off : r.off+i])
+	r.off += i + 1
+	return s
+}
+
+func (r *bodyReader) octet() byte {
+	b := r.octets(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *bodyReader) octets(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if r.off+n > len(r.b) {
+		r.err = errShortBody
+		return nil
+	}
+	b := r.b[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+// tlvs returns the optional parameters left in the body by tag.
+func (r *bodyReader) tlvs() map[uint16][]byte {
+	params := make(map[uint16][]byte)
+	for r.off+4 <= len(r.b) {
+		tag := binary.BigEndian.Uint16(r.b[r.off:])
+		length := int(binary.BigEndian.Uint16(r.b[r.off+2:]))
+		r.off += 4
+		value := r.octets(length)
+		if r.err != nil {
+			break
+		}
+		params[tag] = value
+	}
+	return params
+}
+
+// StatusError is a non-zero command status returned by the SMSC.
+type StatusError struct {
+	CommandId uint32
+	Status    uint32
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("smpp: command 0x%08X failed with status 0x%08X", e.CommandId, e.Status)
+}
+
+// Temporary reports whether resubmitting later may succeed.
+func (e *StatusError) Temporary() bool {
+	switch e.Status {
+	case StatusMsgQueueFul, StatusSysErr, StatusThrottled:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,127 @@
+package smpp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestPduRoundTrip(t *testing.T) {
+	tests := []Pdu{
+		{CommandId: EnquireLink, Seq: 1},
+		{CommandId: SubmitSmResp, Status: StatusThrottled, Seq: 0xFFFFFFFF, Body: []byte("id\x00")},
+		{CommandId: BindTransceiver, Seq: 42, Body: bytes.Repeat([]byte{0xAB}, 300)},
+	}
+
+	for _, want := range tests {
+		var buf bytes.Buffer
+		if err := writePdu(&buf, &want); err != nil {
+			t.Fatal(err)
+		}
+		if got := binary.BigEndian.Uint32(buf.Bytes()); int(got) != headerLen+len(want.Body) {
+			t.Errorf("command 0x%08X: got command_length %d", want.CommandId, got)
+		}
+
+		got, err := readPdu(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.CommandId != want.CommandId || got.Status != want.Status || got.Seq != want.Seq || !bytes.Equal(got.Body, want.Body) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("command 0x%08X: %d bytes left unread", want.CommandId, buf.Len())
+		}
+	}
+}
+
+func TestReadPduRejectsBadLength(t *testing.T) {
+	for _, length := range []uint32{0, headerLen - 1, maxPduLen + 1} {
+		var header [headerLen]byte
+		binary.BigEndian.PutUint32(header[:], length)
+
+		if _, err := readPdu(bytes.NewReader(header[:])); err == nil {
+			t.Errorf("length %d: got no error", length)
+		}
+	}
+}
+
+func TestReadPduTruncatedBody(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writePdu(&buf, &Pdu{CommandId: SubmitSm, Seq: 1, Body: []byte("abcdef")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := readPdu(bytes.NewReader(buf.Bytes()[:buf.Len()-1])); err == nil {
+		t.Error("got no error")
+	}
+}
+
+func TestBodyReader(t *testing.T) {
+	var w bodyWriter
+	w.cString("system")
+	w.octet(0x34)
+	w.cString("")
+	w.Write([]byte{0x00, 0x1E, 0x00, 0x03, 'a', 'b', 0})
+	w.Write([]byte{0x04, 0x27, 0x00, 0x01, 0x02})
+
+	r := &bodyReader{b: w.Bytes()}
+	if got := r.cString(); got != "system" {
+		t.Errorf("got %q", got)
+	}
+	if got := r.octet(); got != 0x34 {
+		t.Errorf("got 0x%02X", got)
+	}
+	if got := r.cString(); got != "" {
+		t.Errorf("got %q", got)
+	}
+
+	tlvs := r.tlvs()
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if !bytes.Equal(tlvs[tagReceiptedMessageId], []byte("ab\x00")) || !bytes.Equal(tlvs[0x0427], []byte{0x02}) {
+		t.Errorf("got tlvs %v", tlvs)
+	}
+}
+
+func TestBodyReaderShortBody(t *testing.T) {
+	r := &bodyReader{b: []byte("no terminator")}
+	if got := r.cString(); got != "" {
+		t.Errorf("got %q", got)
+	}
+	if !errors.Is(r.err, errShortBody) {
+		t.Fatalf("got err %v", r.err)
+	}
+
+	// Reads after the body ran short stay zero.
+	if r.octet() != 0 || r.octets(1) != nil {
+		t.Error("read past a short body")
+	}
+
+	r = &bodyReader{b: []byte{1, 2}}
+	if r.octets(3) != nil || !errors.Is(r.err, errShortBody) {
+		t.Errorf("got err %v", r.err)
+	}
+}
+
+func TestStatusErrorTemporary(t *testing.T) {
+	tests := []struct {
+		status    uint32
+		temporary bool
+	}{
+		{StatusMsgQueueFul, true},
+		{StatusSysErr, true},
+		{StatusThrottled, true},
+		{StatusInvalidCmd, false},
+		{0x0000000B, false}, // ESME_RINVDSTADR
+	}
+
+	for _, tt := range tests {
+		err := &StatusError{CommandId: SubmitSm, Status: tt.status}
+		if err.Temporary() != tt.temporary {
+			t.Errorf("status 0x%08X: got Temporary() = %v", tt.status, err.Temporary())
+		}
+	}
+}
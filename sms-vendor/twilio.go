@@ -0,0 +1,171 @@
+package sms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+const (
+	TwilioModeMessages = "MESSAGES"
+	TwilioModeVerify   = "VERIFY"
+)
+
+// TwilioVendor sends codes as plain messages through the Twilio Messages API,
+// or in VERIFY mode through Twilio Verify with our code as its custom code.
+type TwilioVendor struct {
+	cfg    TwilioConfig
+	client *http.Client
+}
+
+type TwilioConfig struct {
+	AccountSid string `envconfig:"TWILIO_ACCOUNT_SID"`
+	AuthToken  string `envconfig:"TWILIO_AUTH_TOKEN"`
+	Mode       string `envconfig:"TWILIO_MODE" default:"MESSAGES"`
+	// From is the sender number, or a messaging service SID (MG...).
+	From             string `envconfig:"TWILIO_FROM"`
+	VerifyServiceSid string `envconfig:"TWILIO_VERIFY_SERVICE_SID"`
+	// Message is the text sent in MESSAGES mode. {code}, {product} and other
+	// template params are replaced.
+	Message       string `envconfig:"TWILIO_MESSAGE" default:"Your verification code is {code}"`
+	BaseURL       string `envconfig:"TWILIO_BASE_URL" default:"https://api.twilio.com"`
+	VerifyBaseURL string `envconfig:"TWILIO_VERIFY_BASE_URL" default:"https://verify.twilio.com"`
+}
+
+func NewTwilioVendor() (*TwilioVendor, error) {
+	var cfg TwilioConfig
+	err := envconfig.Process("", &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.AccountSid == "" || cfg.AuthToken == "" {
+		return nil, errors.New("twilio account sid and auth token are required")
+	}
+
+	switch cfg.Mode {
+	case TwilioModeMessages:
+		if cfg.From == "" {
+			return nil, errors.New("twilio sender is required in MESSAGES mode")
+		}
+	case TwilioModeVerify:
+		if cfg.VerifyServiceSid == "" {
+			return nil, errors.New("twilio verify service sid is required in VERIFY mode")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported twilio mode: %s", cfg.Mode)
+	}
+
+	return &TwilioVendor{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+func (v *TwilioVendor) SendCode(phoneNumber string, params TemplateParams) (*Receipt, error) {
+	return v.sendCode(phoneNumber, "", params)
+}
+
+// sendCode takes template as the message text; Verify renders its own.
+func (v *TwilioVendor) sendCode(phoneNumber, template string, params TemplateParams) (*Receipt, error) {
+	if v.cfg.Mode == TwilioModeVerify {
+		return v.startVerification(phoneNumber, params["code"])
+	}
+
+	if template == "" {
+		template = v.cfg.Message
+	}
+
+	return v.SendSms(phoneNumber, &Message{Params: params, Text: template})
+}
+
+// SendSms sends a Content API template, or text, through the Messages API
+// in either mode.
+func (v *TwilioVendor) SendSms(phoneNumber string, msg *Message) (*Receipt, error) {
+	if v.cfg.From == "" {
+		return nil, fmt.Errorf("%w: twilio sender is required to send messages", ErrUnsupportedMessage)
+	}
+
+	form := url.Values{}
+	form.Set("To", phoneNumber)
+	if strings.HasPrefix(v.cfg.From, "MG") {
+		form.Set("MessagingServiceSid", v.cfg.From)
+	} else {
+		form.Set("From", v.cfg.From)
+	}
+
+	if contentSid, ok := msg.TemplateIds[ProviderTwilio]; ok {
+		variables, err := msg.Params.JSON()
+		if err != nil {
+			return nil, err
+		}
+		form.Set("ContentSid", contentSid)
+		form.Set("ContentVariables", variables)
+	} else {
+		text, err := msg.render()
+		if err != nil {
+			return nil, err
+		}
+		form.Set("Body", text)
+	}
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", strings.TrimSuffix(v.cfg.BaseURL, "/"), v.cfg.AccountSid)
+	return v.post(endpoint, phoneNumber, form)
+}
+
+// startVerification has Twilio Verify deliver code, leaving validation to us.
+func (v *TwilioVendor) startVerification(phoneNumber, code string) (*Receipt, error) {
+	form := url.Values{}
+	form.Set("To", phoneNumber)
+	form.Set("Channel", "sms")
+	form.Set("CustomCode", code)
+
+	endpoint := fmt.Sprintf("%s/v2/Services/%s/Verifications", strings.TrimSuffix(v.cfg.VerifyBaseURL, "/"), v.cfg.VerifyServiceSid)
+	return v.post(endpoint, phoneNumber, form)
+}
+
+// twilioResponse covers the fields we read from both a created resource and
+// an error.
+type twilioResponse struct {
+	Sid     string `json:"sid"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (v *TwilioVendor) post(endpoint, phoneNumber string, form url.Values) (*Receipt, error) {
+	req, err := http.NewRequest("POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(v.cfg.AccountSid, v.cfg.AuthToken)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		log.Printf("twilio: request failed to %s: %v", phoneNumber, err)
+		return nil, &SendError{Vendor: ProviderTwilio, Retryable: true, Err: err}
+	}
+	defer resp.Body.Close()
+
+	var result twilioResponse
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	_ = json.Unmarshal(body, &result)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		log.Printf("twilio: non-2xx response for %s, status %s: %d %s", phoneNumber, resp.Status, result.Code, result.Message)
+		code := resp.Status
+		if result.Code != 0 {
+			code = strconv.Itoa(result.Code)
+		}
+		return nil, &SendError{Vendor: ProviderTwilio, Code: code, Message: result.Message, Retryable: httpRetryable(resp.StatusCode)}
+	}
+
+	log.Printf("twilio: message %s sent to %s", result.Sid, phoneNumber)
+	return &Receipt{Vendor: ProviderTwilio, MessageID: result.Sid}, nil
+}
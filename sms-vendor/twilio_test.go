@@ -0,0 +1,145 @@
+package sms
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// twilioStub stands in for the Messages and Verify APIs, answering every
+// request with status and body.
+type twilioStub struct {
+	status int
+	body   string
+
+	path string
+	form url.Values
+	user string
+}
+
+func (s *twilioStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.path = r.URL.Path
+	s.user, _, _ = r.BasicAuth()
+	_ = r.ParseForm()
+	s.form = r.PostForm
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(s.status)
+	fmt.Fprint(w, s.body)
+}
+
+func newTwilioTestVendor(t *testing.T, mode string, stub *twilioStub) *TwilioVendor {
+	t.Helper()
+
+	srv := httptest.NewServer(stub)
+	t.Cleanup(srv.Close)
+
+	t.Setenv("TWILIO_ACCOUNT_SID", "AC123")
+	t.Setenv("TWILIO_AUTH_TOKEN", "token")
+	t.Setenv("TWILIO_MODE", mode)
+	t.Setenv("TWILIO_FROM", "+15005550006")
+	t.Setenv("TWILIO_VERIFY_SERVICE_SID", "VA123")
+	t.Setenv("TWILIO_MESSAGE", "Your {product} code is {code}")
+	t.Setenv("TWILIO_BASE_URL", srv.URL)
+	t.Setenv("TWILIO_VERIFY_BASE_URL", srv.URL)
+
+	v, err := NewTwilioVendor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestTwilioSendCodeMessages(t *testing.T) {
+	stub := &twilioStub{status: http.StatusCreated, body: `{"sid": "SM123"}`}
+	v := newTwilioTestVendor(t, TwilioModeMessages, stub)
+
+	receipt, err := v.SendCode("+14155550100", TemplateParams{"code": "1234", "product": "Acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *receipt != (Receipt{Vendor: ProviderTwilio, MessageID: "SM123"}) {
+		t.Errorf("got receipt %+v", receipt)
+	}
+	if stub.path != "/2010-04-01/Accounts/AC123/Messages.json" {
+		t.Errorf("got path %s", stub.path)
+	}
+	if stub.user != "AC123" {
+		t.Errorf("got basic auth user %q", stub.user)
+	}
+	if got := stub.form.Get("Body"); got != "Your Acme code is 1234" {
+		t.Errorf("got body %q", got)
+	}
+	if stub.form.Get("To") != "+14155550100" || stub.form.Get("From") != "+15005550006" {
+		t.Errorf("got form %v", stub.form)
+	}
+}
+
+func TestTwilioSendCodeVerify(t *testing.T) {
+	stub := &twilioStub{status: http.StatusCreated, body: `{"sid": "VE123"}`}
+	v := newTwilioTestVendor(t, TwilioModeVerify, stub)
+
+	receipt, err := v.SendCode("+14155550100", TemplateParams{"code": "1234", "product": "Acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *receipt != (Receipt{Vendor: ProviderTwilio, MessageID: "VE123"}) {
+		t.Errorf("got receipt %+v", receipt)
+	}
+	if stub.path != "/v2/Services/VA123/Verifications" {
+		t.Errorf("got path %s", stub.path)
+	}
+	if stub.form.Get("CustomCode") != "1234" || stub.form.Get("Channel") != "sms" || stub.form.Get("To") != "+14155550100" {
+		t.Errorf("got form %v", stub.form)
+	}
+}
+
+func TestTwilioSendCodeErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		code      string
+		retryable bool
+	}{
+		{"invalid number", http.StatusBadRequest, `{"code": 21211, "message": "Invalid 'To' Phone Number"}`, "21211", false},
+		{"throttled", http.StatusTooManyRequests, `{"code": 20429, "message": "Too Many Requests"}`, "20429", true},
+		{"outage", http.StatusServiceUnavailable, `upstream unavailable`, "503 Service Unavailable", true},
+	}
+
+	for _, mode := range []string{TwilioModeMessages, TwilioModeVerify} {
+		for _, tt := range tests {
+			t.Run(mode+"/"+tt.name, func(t *testing.T) {
+				v := newTwilioTestVendor(t, mode, &twilioStub{status: tt.status, body: tt.body})
+
+				_, err := v.SendCode("+14155550100", TemplateParams{"code": "1234"})
+
+				var sendErr *SendError
+				if !errors.As(err, &sendErr) {
+					t.Fatalf("got %v, want a SendError", err)
+				}
+				if sendErr.Vendor != ProviderTwilio || sendErr.Code != tt.code || sendErr.Retryable != tt.retryable {
+					t.Errorf("got %+v", sendErr)
+				}
+				if IsRetryable(err) != tt.retryable {
+					t.Errorf("IsRetryable = %v, want %v", IsRetryable(err), tt.retryable)
+				}
+			})
+		}
+	}
+}
+
+func TestTwilioSendCodeUnreachable(t *testing.T) {
+	v := newTwilioTestVendor(t, TwilioModeMessages, &twilioStub{})
+	v.cfg.BaseURL = "http://127.0.0.1:0"
+
+	_, err := v.SendCode("+14155550100", TemplateParams{"code": "1234"})
+	if !IsRetryable(err) {
+		t.Errorf("got %v, want a retryable error", err)
+	}
+}
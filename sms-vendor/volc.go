@@ -2,8 +2,12 @@ package sms
 
 import (
 	"fmt"
+	"log"
+	"strconv"
 	"strings"
 
+	"github.com/more-than-code/messaging/phone"
+
 	"github.com/kelseyhightower/envconfig"
 	"github.com/volcengine/volc-sdk-golang/service/sms"
 )
@@ -31,57 +35,94 @@ func NewVolcVendor() (*VolcVendor, error) {
 	return &VolcVendor{cfg: cfg}, nil
 }
 
-func (v *VolcVendor) SendCode(phoneNumber, code string) error {
+func (v *VolcVendor) SendCode(phoneNumber string, params TemplateParams) (*Receipt, error) {
+	return v.sendCode(phoneNumber, "", params)
+}
+
+func (v *VolcVendor) sendCode(phoneNumber, template string, params TemplateParams) (*Receipt, error) {
+	templateParam, err := params.JSON()
+	if err != nil {
+		return nil, err
+	}
+
 	sms.DefaultInstance.Client.SetAccessKey(v.cfg.AccessKey)
 	sms.DefaultInstance.Client.SetSecretKey(v.cfg.SecretKey)
 
-	var tempId string
-	if strings.HasPrefix(phoneNumber, "86") || strings.HasPrefix(phoneNumber, "+86") {
-		tempId = v.cfg.TemplateCn
-	} else {
+	tempId := template
+	if tempId == "" {
 		tempId = v.cfg.Template
+		if phone.CountryCode(phoneNumber) == 86 {
+			tempId = v.cfg.TemplateCn
+		}
 	}
 
 	req := &sms.SmsRequest{
 		SmsAccount:    v.cfg.Account,
 		Sign:          v.cfg.Sign,
 		TemplateID:    tempId,
-		TemplateParam: fmt.Sprintf(`{"code": "%s"}`, code),
+		TemplateParam: templateParam,
 		PhoneNumbers:  phoneNumber,
 		Tag:           "msgs",
 	}
-	result, statusCode, err := sms.DefaultInstance.Send(req)
-	fmt.Printf("result = %+v\n", result)
-	fmt.Printf("statusCode = %+v\n", statusCode)
-	fmt.Printf("err = %+v\n", err)
 
-	return err
+	return v.send(req)
 }
 
-func (v *VolcVendor) SendCodeNProduct(phoneNumber, code, product string) error {
-	sms.DefaultInstance.Client.SetAccessKey(v.cfg.AccessKey)
-	sms.DefaultInstance.Client.SetSecretKey(v.cfg.SecretKey)
+func (v *VolcVendor) SendSms(phoneNumber string, msg *Message) (*Receipt, error) {
+	tempId, ok := msg.TemplateIds[ProviderVolc]
+	if !ok {
+		return nil, fmt.Errorf("%w: volc only sends templates", ErrUnsupportedMessage)
+	}
 
-	var tempId string
-	if strings.HasPrefix(phoneNumber, "86") || strings.HasPrefix(phoneNumber, "+86") {
-		tempId = v.cfg.TemplateCn
-	} else {
-		tempId = v.cfg.Template
+	templateParam, err := msg.Params.JSON()
+	if err != nil {
+		return nil, err
 	}
 
+	sms.DefaultInstance.Client.SetAccessKey(v.cfg.AccessKey)
+	sms.DefaultInstance.Client.SetSecretKey(v.cfg.SecretKey)
+
 	req := &sms.SmsRequest{
 		SmsAccount:    v.cfg.Account,
 		Sign:          v.cfg.Sign,
 		TemplateID:    tempId,
-		TemplateParam: fmt.Sprintf(`{"code": "%s", "product": "%s"}`, code, product),
+		TemplateParam: templateParam,
 		PhoneNumbers:  phoneNumber,
 		Tag:           "msgs",
 	}
+
+	return v.send(req)
+}
+
+func (v *VolcVendor) send(req *sms.SmsRequest) (*Receipt, error) {
 	result, statusCode, err := sms.DefaultInstance.Send(req)
-	fmt.Printf("result = %+v\n", result)
-	fmt.Printf("result.ResponseMetadata.Error = %+v\n", result.ResponseMetadata.Error)
-	fmt.Printf("statusCode = %+v\n", statusCode)
-	fmt.Printf("err = %+v\n", err)
+	if err != nil {
+		log.Printf("volc: SendSms to %s failed: %v", req.PhoneNumbers, err)
+		return nil, volcSendError(ProviderVolc, statusCode, err)
+	}
+
+	if rejection := result.ResponseMetadata.Error; rejection != nil {
+		log.Printf("volc: SendSms to %s rejected: %s %s", req.PhoneNumbers, rejection.Code, rejection.Message)
+		return nil, volcRejection(ProviderVolc, rejection.Code, rejection.Message)
+	}
+
+	receipt := &Receipt{Vendor: ProviderVolc}
+	if result.Result != nil && len(result.Result.MessageID) > 0 {
+		receipt.MessageID = result.Result.MessageID[0]
+	}
+	return receipt, nil
+}
+
+// volcSendError wraps a failed request to Volc or BytePlus. The SDKs return
+// an error for any non-2xx status.
+func volcSendError(vendor string, statusCode int, err error) *SendError {
+	return &SendError{Vendor: vendor, Code: strconv.Itoa(statusCode), Retryable: httpRetryable(statusCode), Err: err}
+}
 
-	return err
+// volcRejection wraps an error reported in a Volc or BytePlus response.
+// Parameter errors, which cover invalid numbers and template params, are the
+// message's fault; anything else is the vendor's.
+func volcRejection(vendor, code, message string) *SendError {
+	atFault := strings.HasPrefix(code, "InvalidParameter") || strings.HasPrefix(code, "MissingParameter")
+	return &SendError{Vendor: vendor, Code: code, Message: message, Retryable: !atFault}
 }
@@ -0,0 +1,173 @@
+package messaging
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/more-than-code/messaging/constant"
+	"github.com/more-than-code/messaging/pb"
+	"github.com/more-than-code/messaging/repository"
+	"github.com/more-than-code/messaging/totp"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// totpAttemptScope counts wrong TOTP codes, which no pending record tracks.
+const totpAttemptScope = "totp"
+
+// EnrollTotp starts an authenticator-app enrollment for identity. The secret
+// only replaces an existing enrollment once ConfirmTotpEnrollment succeeds.
+func (s *Server) EnrollTotp(ctx context.Context, req *pb.EnrollTotpRequest) (*pb.EnrollTotpResponse, error) {
+	if req.Identity == "" {
+		return nil, status.Error(codes.InvalidArgument, "identity is required")
+	}
+
+	issuer := req.Issuer
+	if issuer == "" {
+		issuer = s.cfg.ProductName
+	}
+	account := req.AccountName
+	if account == "" {
+		account = req.Identity
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	info := repository.TotpInfo{Secret: secret, CreatedAt: time.Now()}
+	err = s.repo.SetPendingTotpInfo(ctx, req.Identity, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[EnrollTotp] Pending enrollment created for %s", req.Identity)
+
+	return &pb.EnrollTotpResponse{
+		Secret:           totp.EncodeSecret(secret),
+		OtpauthUri:       totp.URI(issuer, account, secret),
+		ExpiresInSeconds: int32(repository.PendingTotpTTL / time.Second),
+	}, nil
+}
+
+// ConfirmTotpEnrollment activates the pending enrollment of identity once the
+// user proves their app produces matching codes.
+func (s *Server) ConfirmTotpEnrollment(ctx context.Context, req *pb.ConfirmTotpEnrollmentRequest) (*pb.ConfirmTotpEnrollmentResponse, error) {
+	if req.Identity == "" {
+		return nil, status.Error(codes.InvalidArgument, "identity is required")
+	}
+
+	locked, err := s.repo.LockoutRemaining(ctx, req.Identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if locked > 0 {
+		log.Printf("[ConfirmTotpEnrollment] %s locked out for %s", req.Identity, locked)
+		return &pb.ConfirmTotpEnrollmentResponse{Status: pb.TotpValidationStatus_TOTP_VALIDATION_STATUS_LOCKED, Msg: string(constant.MsgTotpLocked), RetryAfterSeconds: durationToSeconds(locked)}, nil
+	}
+
+	pending, err := s.repo.GetTotpInfo(ctx, req.Identity, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if pending == nil {
+		return &pb.ConfirmTotpEnrollmentResponse{Status: pb.TotpValidationStatus_TOTP_VALIDATION_STATUS_NOT_ENROLLED, Msg: string(constant.MsgTotpNotEnrolled)}, nil
+	}
+
+	counter, ok := totp.Match(pending.Secret, req.Code, time.Now(), s.cfg.TotpSkew)
+	if !ok {
+		log.Printf("[ConfirmTotpEnrollment] Code mismatch for %s", req.Identity)
+
+		locked, err = s.countFailedGuess(ctx, totpAttemptScope, req.Identity)
+		if err != nil {
+			return nil, err
+		}
+
+		if locked > 0 {
+			log.Printf("[ConfirmTotpEnrollment] Too many wrong codes, locked out for %s", locked)
+			return &pb.ConfirmTotpEnrollmentResponse{Status: pb.TotpValidationStatus_TOTP_VALIDATION_STATUS_LOCKED, Msg: string(constant.MsgTotpLocked), RetryAfterSeconds: durationToSeconds(locked)}, nil
+		}
+
+		return &pb.ConfirmTotpEnrollmentResponse{Status: pb.TotpValidationStatus_TOTP_VALIDATION_STATUS_INVALID, Msg: string(constant.MsgTotpInvalid)}, nil
+	}
+
+	err = s.repo.ConfirmTotpInfo(ctx, req.Identity, pending, counter)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.ClearFailedAttempts(ctx, totpAttemptScope, req.Identity)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[ConfirmTotpEnrollment] Enrollment confirmed for %s", req.Identity)
+
+	return &pb.ConfirmTotpEnrollmentResponse{Status: pb.TotpValidationStatus_TOTP_VALIDATION_STATUS_VALID, Msg: string(constant.MsgTotpValid)}, nil
+}
+
+// ValidateTotp checks code against the confirmed enrollment of identity,
+// allowing TotpSkew steps of clock drift. Each time step is accepted once.
+func (s *Server) ValidateTotp(ctx context.Context, req *pb.ValidateTotpRequest) (*pb.ValidateTotpResponse, error) {
+	if req.Identity == "" {
+		return nil, status.Error(codes.InvalidArgument, "identity is required")
+	}
+
+	locked, err := s.repo.LockoutRemaining(ctx, req.Identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if locked > 0 {
+		log.Printf("[ValidateTotp] %s locked out for %s", req.Identity, locked)
+		return &pb.ValidateTotpResponse{Status: pb.TotpValidationStatus_TOTP_VALIDATION_STATUS_LOCKED, Msg: string(constant.MsgTotpLocked), RetryAfterSeconds: durationToSeconds(locked)}, nil
+	}
+
+	info, err := s.repo.GetTotpInfo(ctx, req.Identity, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if info == nil {
+		return &pb.ValidateTotpResponse{Status: pb.TotpValidationStatus_TOTP_VALIDATION_STATUS_NOT_ENROLLED, Msg: string(constant.MsgTotpNotEnrolled)}, nil
+	}
+
+	counter, ok := totp.Match(info.Secret, req.Code, time.Now(), s.cfg.TotpSkew)
+	if !ok {
+		log.Printf("[ValidateTotp] Code mismatch for %s", req.Identity)
+
+		locked, err = s.countFailedGuess(ctx, totpAttemptScope, req.Identity)
+		if err != nil {
+			return nil, err
+		}
+
+		if locked > 0 {
+			log.Printf("[ValidateTotp] Too many wrong codes, locked out for %s", locked)
+			return &pb.ValidateTotpResponse{Status: pb.TotpValidationStatus_TOTP_VALIDATION_STATUS_LOCKED, Msg: string(constant.MsgTotpLocked), RetryAfterSeconds: durationToSeconds(locked)}, nil
+		}
+
+		return &pb.ValidateTotpResponse{Status: pb.TotpValidationStatus_TOTP_VALIDATION_STATUS_INVALID, Msg: string(constant.MsgTotpInvalid)}, nil
+	}
+
+	fresh, err := s.repo.AdvanceTotpCounter(ctx, req.Identity, counter)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fresh {
+		log.Printf("[ValidateTotp] Replayed code for %s", req.Identity)
+		return &pb.ValidateTotpResponse{Status: pb.TotpValidationStatus_TOTP_VALIDATION_STATUS_REPLAYED, Msg: string(constant.MsgTotpReplayed)}, nil
+	}
+
+	err = s.repo.ClearFailedAttempts(ctx, totpAttemptScope, req.Identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ValidateTotpResponse{Status: pb.TotpValidationStatus_TOTP_VALIDATION_STATUS_VALID, Msg: string(constant.MsgTotpValid)}, nil
+}
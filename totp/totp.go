@@ -0,0 +1,96 @@
+// Package totp implements RFC 6238 time-based one-time passwords with the
+// parameters authenticator apps assume by default: HMAC-SHA1, 6 digits and a
+// 30 second period.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	Digits     = 6
+	Period     = 30 * time.Second
+	SecretSize = 20
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func GenerateSecret() ([]byte, error) {
+	secret := make([]byte, SecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// EncodeSecret returns the unpadded base32 form users type into their app.
+func EncodeSecret(secret []byte) string {
+	return secretEncoding.EncodeToString(secret)
+}
+
+// Counter returns the time step t falls into.
+func Counter(t time.Time) uint64 {
+	return uint64(t.Unix()) / uint64(Period/time.Second)
+}
+
+// Code computes the HOTP value (RFC 4226) for counter.
+func Code(secret []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%1000000)
+}
+
+// Match looks for code in the time steps within skew of t and returns the
+// counter it matched, so callers can reject it being used again.
+func Match(secret []byte, code string, t time.Time, skew int) (uint64, bool) {
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := Counter(t)
+	for i := -skew; i <= skew; i++ {
+		if i < 0 && uint64(-i) > current {
+			continue
+		}
+		counter := current + uint64(i)
+		if subtle.ConstantTimeCompare([]byte(Code(secret, counter)), []byte(code)) == 1 {
+			return counter, true
+		}
+	}
+
+	return 0, false
+}
+
+// URI builds the otpauth:// provisioning URI encoded in enrollment QR codes.
+func URI(issuer, account string, secret []byte) string {
+	params := url.Values{}
+	params.Set("secret", EncodeSecret(secret))
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(Digits))
+	params.Set("period", fmt.Sprint(int(Period/time.Second)))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: params.Encode(),
+	}
+	return u.String()
+}
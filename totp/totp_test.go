@@ -0,0 +1,102 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 key of the RFC 6238 test vectors.
+var rfcSecret = []byte("12345678901234567890")
+
+func TestCodeRfc6238(t *testing.T) {
+	// RFC 6238 appendix B, truncated from 8 digits to Digits.
+	tests := []struct {
+		unix    int64
+		counter uint64
+		code    string
+	}{
+		{59, 0x1, "287082"},
+		{1111111109, 0x23523EC, "081804"},
+		{1111111111, 0x23523ED, "050471"},
+		{1234567890, 0x273EF07, "005924"},
+		{2000000000, 0x3F940AA, "279037"},
+	}
+
+	for _, tt := range tests {
+		counter := Counter(time.Unix(tt.unix, 0))
+		if counter != tt.counter {
+			t.Errorf("Counter(%d) = 0x%X, want 0x%X", tt.unix, counter, tt.counter)
+		}
+		if code := Code(rfcSecret, counter); code != tt.code {
+			t.Errorf("T=%d: Code = %s, want %s", tt.unix, code, tt.code)
+		}
+	}
+}
+
+func TestMatchSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Counter(now)
+
+	tests := []struct {
+		name    string
+		counter uint64
+		skew    int
+		ok      bool
+	}{
+		{"current", current, 0, true},
+		{"previous step", current - 1, 1, true},
+		{"next step", current + 1, 1, true},
+		{"previous step without skew", current - 1, 0, false},
+		{"two steps back", current - 2, 1, false},
+		{"two steps ahead", current + 2, 1, false},
+	}
+
+	for _, tt := range tests {
+		counter, ok := Match(rfcSecret, Code(rfcSecret, tt.counter), now, tt.skew)
+		if ok != tt.ok {
+			t.Errorf("%s: got ok %v", tt.name, ok)
+		}
+		if ok && counter != tt.counter {
+			t.Errorf("%s: matched counter %d, want %d", tt.name, counter, tt.counter)
+		}
+	}
+}
+
+func TestMatchCounterZero(t *testing.T) {
+	// Skewing back from the first step mustn't wrap around to the last.
+	now := time.Unix(10, 0)
+
+	if counter, ok := Match(rfcSecret, Code(rfcSecret, 0), now, 1); !ok || counter != 0 {
+		t.Errorf("got counter %d, ok %v", counter, ok)
+	}
+	if counter, ok := Match(rfcSecret, Code(rfcSecret, 1), now, 1); !ok || counter != 1 {
+		t.Errorf("got counter %d, ok %v", counter, ok)
+	}
+	if _, ok := Match(rfcSecret, Code(rfcSecret, ^uint64(0)), now, 1); ok {
+		t.Error("matched the code of the last counter")
+	}
+}
+
+func TestMatchRejectsMalformedCodes(t *testing.T) {
+	now := time.Unix(59, 0)
+
+	for _, code := range []string{"", "28708", "2870820", "94287082"} {
+		if _, ok := Match(rfcSecret, code, now, 1); ok {
+			t.Errorf("matched %q", code)
+		}
+	}
+}
+
+func TestURI(t *testing.T) {
+	got := URI("Acme Inc", "jane+2fa@example.com", rfcSecret)
+	want := "otpauth://totp/Acme%20Inc:jane+2fa@example.com?algorithm=SHA1&digits=6&issuer=Acme+Inc&period=30&secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestEncodeSecret(t *testing.T) {
+	if got := EncodeSecret([]byte("ab")); got != "MFRA" {
+		t.Errorf("got %s, want the unpadded MFRA", got)
+	}
+}
@@ -17,12 +17,3 @@ func DomainFromAddress(address string) string {
 
 	return ""
 }
-
-func Contains[T comparable](s []T, e T) bool {
-	for _, v := range s {
-		if v == e {
-			return true
-		}
-	}
-	return false
-}
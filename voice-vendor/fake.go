@@ -0,0 +1,42 @@
+package voice
+
+import (
+	"log"
+	"sync"
+)
+
+type Call struct {
+	PhoneNumber string
+	Script      string
+}
+
+// FakeVendor records calls instead of placing them, for local development
+// and tests. Err, when set, is returned from every call.
+type FakeVendor struct {
+	Err error
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (v *FakeVendor) CallCode(phoneNumber, code string) error {
+	if v.Err != nil {
+		return v.Err
+	}
+
+	script := Script("Your verification code is", code, 1)
+	log.Printf("voice: fake call to %s: %s", phoneNumber, script)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.calls = append(v.calls, Call{PhoneNumber: phoneNumber, Script: script})
+
+	return nil
+}
+
+// Calls returns the calls placed so far.
+func (v *FakeVendor) Calls() []Call {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]Call(nil), v.calls...)
+}
@@ -0,0 +1,86 @@
+package voice
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// HttpVendor places calls through a JSON text-to-speech call API that takes
+// the callee, caller ID, script and voice language.
+type HttpVendor struct {
+	cfg    HttpConfig
+	client *http.Client
+}
+
+type HttpConfig struct {
+	URL      string `envconfig:"VOICE_API_URL"`
+	APIKey   string `envconfig:"VOICE_API_KEY"`
+	CallerId string `envconfig:"VOICE_CALLER_ID"`
+	Language string `envconfig:"VOICE_LANGUAGE" default:"en-US"`
+	Intro    string `envconfig:"VOICE_INTRO" default:"Your verification code is"`
+	Repeat   int    `envconfig:"VOICE_REPEAT" default:"2"`
+}
+
+func NewHttpVendor() (*HttpVendor, error) {
+	var cfg HttpConfig
+	err := envconfig.Process("", &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.URL == "" || cfg.APIKey == "" {
+		return nil, errors.New("voice api url and key are required")
+	}
+
+	return &HttpVendor{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+type callRequest struct {
+	To       string `json:"to"`
+	From     string `json:"from,omitempty"`
+	Text     string `json:"text"`
+	Language string `json:"language"`
+}
+
+func (v *HttpVendor) CallCode(phoneNumber, code string) error {
+	payload, err := json.Marshal(callRequest{
+		To:       phoneNumber,
+		From:     v.cfg.CallerId,
+		Text:     Script(v.cfg.Intro, code, v.cfg.Repeat),
+		Language: v.cfg.Language,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshaling payload: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", v.cfg.URL, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("error creating HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+v.cfg.APIKey)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		log.Printf("voice: request failed to %s: %v", phoneNumber, err)
+		return fmt.Errorf("error sending request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		log.Printf("voice: non-2xx response for %s, status %s: %s", phoneNumber, resp.Status, body)
+		return fmt.Errorf("failed to place call, HTTP status: %v", resp.Status)
+	}
+
+	log.Printf("voice: call placed to %s", phoneNumber)
+	return nil
+}
@@ -0,0 +1,27 @@
+package voice
+
+import "strings"
+
+// VoiceVendor places text-to-speech calls that read a code to a phone
+// number.
+type VoiceVendor interface {
+	CallCode(phoneNumber, code string) error
+}
+
+// spokenCode separates the characters of code so text-to-speech reads them
+// one by one, e.g. "4821" as "4, 8, 2, 1" rather than a number.
+func spokenCode(code string) string {
+	return strings.Join(strings.Split(code, ""), ", ")
+}
+
+// Script is what a call says: intro and the code, repeated repeat times.
+func Script(intro, code string, repeat int) string {
+	spoken := intro + " " + spokenCode(code) + "."
+
+	script := make([]string, max(repeat, 1))
+	for i := range script {
+		script[i] = spoken
+	}
+
+	return strings.Join(script, " ")
+}